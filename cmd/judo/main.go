@@ -11,6 +11,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -21,6 +22,7 @@ import (
 	"judo-cli-module/internal/docker"
 	"judo-cli-module/internal/help"
 	"judo-cli-module/internal/session"
+	"judo-cli-module/internal/utils"
 )
 
 // Build information. Populated at build-time.
@@ -34,6 +36,7 @@ var (
 func main() {
 	// Ensure Docker client is properly closed when the application exits
 	defer docker.CloseDockerClient()
+	docker.CLIVersion = version
 
 	var rootCmd = &cobra.Command{
 		Use:   "judo",
@@ -42,11 +45,15 @@ func main() {
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			config.LoadProperties()
 			config.SetupEnvironment()
+			utils.SetColor(config.GetConfig().UseColor())
 		},
 	}
 
 	// Common flags
-	rootCmd.PersistentFlags().StringVarP(&config.Profile, "env", "e", "judo", "Use alternate environment")
+	rootCmd.PersistentFlags().StringVarP(&config.Profile, "env", "e", config.DefaultProfile(), "Use alternate environment (default from JUDO_PROFILE)")
+	rootCmd.PersistentFlags().StringVarP(&config.ProjectDir, "project-dir", "C", "", "Run as if judo was started in this directory")
+	rootCmd.PersistentFlags().StringVar(&config.Options.PullPolicy, "pull", docker.PullMissing, "Image pull policy: missing | always")
+	rootCmd.PersistentFlags().BoolVar(&config.Options.Offline, "offline", false, "Never pull images; use only locally available ones")
 
 	// Add commands
 	rootCmd.AddCommand(
@@ -64,15 +71,26 @@ func main() {
 		commands.CreateStartCommand(),
 		commands.CreateStopCommand(),
 		commands.CreateStatusCommand(),
+		commands.CreatePsCommand(),
+		commands.CreateComposeCommand(),
+		commands.CreateImagesCommand(),
+		commands.CreateWatchServicesCommand(),
 		commands.CreateLogCommand(),
 		commands.CreateInitCommand(),
+		commands.CreateConfigCommand(),
 		commands.CreateSelfUpdateCommand(version),
 		createSessionCommand(),
 		createVersionCommand(),
 	)
 
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := utils.InterruptContext(context.Background())
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		fmt.Println(err)
+		if hint := commands.ErrorHint(err); hint != "" {
+			fmt.Println(hint)
+		}
 		os.Exit(1)
 	}
 }
@@ -82,7 +100,7 @@ func createSessionCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "session",
 		Short: "Start interactive JUDO CLI session",
-		Long:  "Start an interactive session with command history, auto-completion, and persistent state",
+		Long:  "Start an interactive session with command history, auto-completion, and persistent state.\nUse 'use <profile>' inside the session to switch profiles; -e on a single command only applies to that command.",
 		Run: func(cmd *cobra.Command, args []string) {
 			session.StartInteractiveSession()
 		},
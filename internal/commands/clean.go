@@ -0,0 +1,271 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/docker"
+	"judo-cli-module/internal/help"
+	"judo-cli-module/internal/karaf"
+	"judo-cli-module/internal/utils"
+)
+
+// cleanComponents are the parts of the environment --only and --keep select.
+var cleanComponents = []string{"postgres", "keycloak", "filestore", "karaf", "compose"}
+
+// cleanItem is one resource removed by 'judo clean'.
+type cleanItem struct {
+	kind   string // container, volume, network, directory, ...
+	name   string
+	needs  []string // components that must all be cleaned to remove it
+	data   bool     // holds user data, removal is confirmed first
+	exists func(ctx context.Context) bool
+	remove func(ctx context.Context) error
+}
+
+func (i cleanItem) String() string { return i.kind + " " + i.name }
+
+func CreateCleanCommand() *cobra.Command {
+	var dryRun, yes bool
+	var only, keep []string
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Stop postgresql docker container and clear data.",
+		Long:  help.CleanLongHelp(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Check if JUDO project is initialized
+			if err := requireJudoProject(); err != nil {
+				return err
+			}
+			selected, err := selectCleanComponents(only, keep)
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			cfg := config.GetConfig()
+			if !docker.IsDockerRunning(ctx) {
+				fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  %v; Docker resources are skipped\x1b[0m\n", docker.ErrDaemonUnreachable)
+			}
+			if dryRun {
+				printCleanPlan(ctx, cfg, selected)
+				return nil
+			}
+
+			var items []cleanItem
+			for _, item := range cleanPlan(cfg) {
+				if selected.all(item.needs) && item.exists(ctx) {
+					items = append(items, item)
+				}
+			}
+			if len(items) == 0 {
+				fmt.Println("Nothing to clean.")
+				return nil
+			}
+			if !yes {
+				ok, err := confirmDataRemoval(items)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return errors.New("clean aborted; nothing was removed")
+				}
+			}
+			return removeCleanItems(ctx, items)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List what would be removed and whether it exists, without removing anything")
+	cmd.Flags().StringSliceVar(&only, "only", nil, "Clean only these components: "+strings.Join(cleanComponents, ","))
+	cmd.Flags().StringSliceVar(&keep, "keep", nil, "Clean everything except these components")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask before removing data volumes")
+	return cmd
+}
+
+// componentSet is the set of components selected for cleaning.
+type componentSet map[string]bool
+
+func (s componentSet) all(components []string) bool {
+	for _, c := range components {
+		if !s[c] {
+			return false
+		}
+	}
+	return true
+}
+
+func selectCleanComponents(only, keep []string) (componentSet, error) {
+	if len(only) > 0 && len(keep) > 0 {
+		return nil, errors.New("--only and --keep cannot be combined")
+	}
+	for _, c := range append(slices.Clone(only), keep...) {
+		if !slices.Contains(cleanComponents, c) {
+			return nil, fmt.Errorf("unknown component %q (use %s)", c, strings.Join(cleanComponents, ", "))
+		}
+	}
+	selected := componentSet{}
+	for _, c := range cleanComponents {
+		if len(only) > 0 {
+			selected[c] = slices.Contains(only, c)
+		} else {
+			selected[c] = !slices.Contains(keep, c)
+		}
+	}
+	return selected, nil
+}
+
+// cleanPlan lists everything 'judo clean' may remove, in removal order:
+// containers before the network and volumes they use.
+func cleanPlan(cfg *config.Config) []cleanItem {
+	var items []cleanItem
+	for _, env := range docker.ComposeEnvs(cfg) {
+		items = append(items, cleanItem{
+			kind: "compose environment", name: env.Name, needs: []string{"compose"}, data: true,
+			exists: func(ctx context.Context) bool { return docker.ComposeProjectExists(ctx, env.Dir) },
+			remove: func(ctx context.Context) error { return docker.ComposeDown(ctx, env.Dir, true) },
+		})
+	}
+	if cfg.Runtime == "karaf" {
+		items = append(items, cleanItem{
+			kind: "directory", name: cfg.KarafDir, needs: []string{"karaf"},
+			exists: func(context.Context) bool { return cfg.KarafDir != "" && utils.FileExists(cfg.KarafDir) },
+			remove: func(context.Context) error {
+				karaf.StopKaraf(cfg.KarafDir)
+				return os.RemoveAll(cfg.KarafDir)
+			},
+		})
+	}
+	container := func(name, component string) cleanItem {
+		return cleanItem{
+			kind: "container", name: name, needs: []string{component},
+			exists: func(ctx context.Context) bool {
+				exists, _ := docker.ContainerExists(ctx, name)
+				return exists
+			},
+			remove: func(ctx context.Context) error { return docker.RemoveDockerInstance(ctx, name) },
+		}
+	}
+	volume := func(name, component string, data bool) cleanItem {
+		return cleanItem{
+			kind: "volume", name: name, needs: []string{component}, data: data,
+			exists: func(ctx context.Context) bool { return docker.DockerVolumeExists(ctx, name) },
+			remove: func(ctx context.Context) error { return docker.RemoveDockerVolume(ctx, name) },
+		}
+	}
+	names := cfg.Names()
+	items = append(items,
+		container(names.PostgresContainer, "postgres"),
+		container(names.KeycloakContainer, "keycloak"),
+		cleanItem{
+			kind: "network", name: names.Network, needs: []string{"postgres", "keycloak"},
+			exists: func(ctx context.Context) bool { return docker.DockerNetworkExists(ctx, names.Network) },
+			remove: func(ctx context.Context) error { return docker.RemoveDockerNetwork(ctx, names.Network) },
+		},
+		volume(names.CertsVolume, "keycloak", false),
+		volume(names.PostgresDBVolume, "postgres", true),
+		volume(names.PostgresDataVolume, "postgres", true),
+		volume(names.FilestoreVolume, "filestore", true),
+		cleanItem{
+			kind: "state file", name: cfg.StatePath(), needs: []string{"postgres", "keycloak", "karaf"},
+			exists: func(context.Context) bool { return utils.FileExists(cfg.StatePath()) },
+			remove: func(context.Context) error { return cfg.ClearState() },
+		},
+	)
+	return items
+}
+
+// printCleanPlan shows every resource of the plan: whether it would be
+// removed, kept or is not there.
+func printCleanPlan(ctx context.Context, cfg *config.Config, selected componentSet) {
+	fmt.Println("Dry run, nothing is removed:")
+	for _, item := range cleanPlan(cfg) {
+		exists := item.exists(ctx)
+		switch {
+		case !selected.all(item.needs):
+			fmt.Fprintf(utils.Stdout, "  \x1b[36mkeep\x1b[0m          %s\n", item)
+		case !exists:
+			fmt.Fprintf(utils.Stdout, "  \x1b[90mnot found\x1b[0m     %s\n", item)
+		case item.data:
+			fmt.Fprintf(utils.Stdout, "  \x1b[33mwould remove\x1b[0m  %s (data)\n", item)
+		default:
+			fmt.Fprintf(utils.Stdout, "  \x1b[33mwould remove\x1b[0m  %s\n", item)
+		}
+	}
+}
+
+// confirmDataRemoval asks before data volumes are removed; clean without
+// data volumes needs no confirmation.
+func confirmDataRemoval(items []cleanItem) (bool, error) {
+	var data []string
+	for _, item := range items {
+		if item.data {
+			data = append(data, item.String())
+		}
+	}
+	return confirmDataLoss(data)
+}
+
+// confirmInput is where confirmDataLoss reads the answer from.
+var confirmInput = os.Stdin
+
+// errNotInteractive is returned instead of asking when nobody can answer.
+var errNotInteractive = errors.New("refusing to delete data non-interactively; pass --yes")
+
+// confirmDataLoss lists the data about to be removed and asks before going
+// on; it confirms at once when data is empty. Without a terminal to ask on
+// (CI, piped input) it fails with errNotInteractive.
+func confirmDataLoss(data []string) (bool, error) {
+	if len(data) == 0 {
+		return true, nil
+	}
+	if !utils.IsTerminal(confirmInput) {
+		return false, errNotInteractive
+	}
+	fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  Clean deletes data that cannot be recovered:\x1b[0m\n")
+	for _, d := range data {
+		fmt.Fprintf(utils.Stdout, "\x1b[33m   • %s\x1b[0m\n", d)
+	}
+	fmt.Fprintf(utils.Stdout, "Are you sure you want to continue? (use --yes to skip this question) [y/N]: ")
+	answer := ""
+	scanner := utils.NewScanner(confirmInput)
+	if scanner.Scan() {
+		answer = strings.TrimSpace(scanner.Text())
+	}
+	return strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes"), nil
+}
+
+// removeCleanItems removes every item, continuing after failures, and
+// reports what could not be removed.
+func removeCleanItems(ctx context.Context, items []cleanItem) error {
+	var failed []string
+	for _, item := range items {
+		fmt.Printf("Removing %s...\n", item)
+		if err := item.remove(ctx); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", item, err))
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Fprintf(utils.Stdout, "\x1b[31m❌ Removed %d of %d, failed:\x1b[0m\n", len(items)-len(failed), len(items))
+		for _, f := range failed {
+			fmt.Fprintf(utils.Stdout, "\x1b[31m   • %s\x1b[0m\n", f)
+		}
+		return fmt.Errorf("clean failed to remove %d resource(s)", len(failed))
+	}
+	fmt.Fprintf(utils.Stdout, "\x1b[32m✅ Removed %d resource(s)\x1b[0m\n", len(items))
+	return nil
+}
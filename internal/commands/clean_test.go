@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"judo-cli-module/internal/config"
+)
+
+func TestSelectCleanComponents(t *testing.T) {
+	all, err := selectCleanComponents(nil, nil)
+	require.NoError(t, err)
+	assert.True(t, all.all(cleanComponents))
+
+	only, err := selectCleanComponents([]string{"postgres", "karaf"}, nil)
+	require.NoError(t, err)
+	assert.True(t, only.all([]string{"postgres", "karaf"}))
+	assert.False(t, only["keycloak"])
+	assert.False(t, only.all([]string{"postgres", "keycloak"}), "the shared network needs both")
+
+	keep, err := selectCleanComponents(nil, []string{"filestore"})
+	require.NoError(t, err)
+	assert.False(t, keep["filestore"])
+	assert.True(t, keep.all([]string{"postgres", "keycloak", "karaf", "compose"}))
+
+	_, err = selectCleanComponents([]string{"postgres"}, []string{"karaf"})
+	assert.Error(t, err)
+	_, err = selectCleanComponents([]string{"mysql"}, nil)
+	assert.ErrorContains(t, err, `unknown component "mysql"`)
+}
+
+func TestCleanPlanMarksDataVolumes(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{ModelDir: dir, AppDir: dir, AppName: "app", SchemaName: "schema", KeycloakName: "kc", Runtime: "karaf", KarafDir: dir + "/.karaf"}
+
+	data := map[string]bool{}
+	for _, item := range cleanPlan(cfg) {
+		data[item.String()] = item.data
+	}
+	assert.Equal(t, map[string]bool{
+		"directory " + cfg.KarafDir:     false,
+		"container postgres-schema":     false,
+		"container keycloak-kc":         false,
+		"network app":                   false,
+		"volume app_certs":              false,
+		"volume schema_postgresql_db":   true,
+		"volume schema_postgresql_data": true,
+		"volume app_filestore":          true,
+		"state file " + cfg.StatePath(): false,
+	}, data)
+}
+
+func TestConfirmDataLossRefusesWithoutTerminal(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "answer")
+	require.NoError(t, os.WriteFile(input, []byte("y\n"), 0o644))
+	f, err := os.Open(input)
+	require.NoError(t, err)
+	defer f.Close()
+	stdin := confirmInput
+	confirmInput = f
+	t.Cleanup(func() { confirmInput = stdin })
+
+	ok, err := confirmDataLoss([]string{"volume shop_postgresql_db"})
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, errNotInteractive)
+
+	ok, err = confirmDataLoss(nil)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+}
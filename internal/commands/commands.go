@@ -12,11 +12,15 @@ package commands
 
 import (
 	"bufio"
+	"context"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -27,6 +31,7 @@ import (
 	"judo-cli-module/internal/docker"
 	"judo-cli-module/internal/help"
 	"judo-cli-module/internal/karaf"
+	"judo-cli-module/internal/rollback"
 	"judo-cli-module/internal/selfupdate"
 	"judo-cli-module/internal/utils"
 )
@@ -73,7 +78,7 @@ func CreateBuildCommand() *cobra.Command {
 		Use:   "build",
 		Short: "Build project",
 		Long:  help.BuildLongHelp(),
-		Run:   runBuild,
+		RunE:  runBuild,
 	}
 
 	cmd.Flags().BoolVarP(&config.Options.BuildParallel, "build-parallel", "p", false, "Parallel maven build")
@@ -100,11 +105,11 @@ func CreateRecklessCommand() *cobra.Command {
 		Use:   "reckless",
 		Short: "Build & run fast (skips validations, favors speed)",
 		Long:  help.RecklessLongHelp(),
-		Run: func(cmd *cobra.Command, _ []string) {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			config.Options.Reckless = true
 			config.Options.QuickMode = true
 			config.Options.BuildKaraf = false // match bash defaults for reckless build matrix
-			runBuild(cmd, nil)
+			return runBuild(cmd, nil)
 		},
 	}
 }
@@ -142,17 +147,21 @@ func CreateStatusCommand() *cobra.Command {
 		Use:   "status",
 		Short: "Print status of Karaf/Keycloak/PostgreSQL containers and resources",
 		Long:  help.StatusLongHelp(),
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			// Check if JUDO project is initialized
 			if err := requireJudoProject(); err != nil {
 				return err
 			}
+			ctx := cmd.Context()
 			cfg := config.GetConfig()
+			names := cfg.Names()
 			fmt.Println("Runtime:", cfg.Runtime, " DB:", cfg.DBType)
+			if cfg.Runtime == "compose" {
+				return printComposeStatus(ctx, cfg)
+			}
 			if cfg.Runtime == "karaf" {
-				karafDir := filepath.Join(cfg.ModelDir, "application", ".karaf")
 				// Karaf
-				if karaf.KarafRunning(karafDir) {
+				if karaf.KarafRunning(cfg.KarafDir) {
 					fmt.Println("Karaf is running")
 				} else {
 					fmt.Println("Karaf is not running")
@@ -160,36 +169,46 @@ func CreateStatusCommand() *cobra.Command {
 
 				// Postgres (if applicable)
 				if cfg.DBType == "postgresql" {
-					pgName := "postgres-" + cfg.SchemaName
-					if docker.DockerInstanceRunning(pgName) {
+					pgName := names.PostgresContainer
+					printContainerImage(ctx, "PostgreSQL", pgName, cfg.PostgresImageRef(), cfg.PostgresPort, cfg.PostgresSettings(), nil)
+					if docker.DockerInstanceRunning(ctx, pgName) {
 						fmt.Println("PostgreSQL is running")
 					} else {
 						fmt.Println("PostgreSQL is not running")
-						if docker.ContainerExists(pgName) {
+						if exists, _ := docker.ContainerExists(ctx, pgName); exists {
 							fmt.Println("PostgreSQL container exists")
 						} else {
 							fmt.Println("PostgreSQL container does not exist")
 						}
-						if docker.DockerVolumeExists(cfg.AppName + "_postgresql_db") {
+						if docker.DockerVolumeExists(ctx, names.PostgresDBVolume) {
 							fmt.Println("PostgreSQL db volume exists")
 						} else {
 							fmt.Println("PostgreSQL db volume does not exist")
 						}
-						if docker.DockerVolumeExists(cfg.AppName + "_postgresql_data") {
+						if docker.DockerVolumeExists(ctx, names.PostgresDataVolume) {
 							fmt.Println("PostgreSQL data volume exists")
 						} else {
 							fmt.Println("PostgreSQL data volume does not exist")
 						}
 					}
+					legacy, _ := docker.LegacyPostgresVolumes(ctx, cfg)
+					for _, v := range legacy {
+						fmt.Printf("\x1b[33m⚠️  Volume %s was created under an earlier name; the next start copies it to %s unless that exists\x1b[0m\n", v.Name, v.Current)
+					}
 				}
 
 				// Keycloak
-				kcName := "keycloak-" + cfg.KeycloakName
-				if docker.DockerInstanceRunning(kcName) {
+				kcName := names.KeycloakContainer
+				var kcLabels map[string]string
+				if creds, err := cfg.Credentials(); err == nil {
+					kcLabels = docker.DBCredentialsLabels(cfg, creds)
+				}
+				printContainerImage(ctx, "Keycloak", kcName, cfg.KeycloakImageRef(), cfg.KeycloakPort, cfg.KeycloakSettings(), kcLabels)
+				if docker.DockerInstanceRunning(ctx, kcName) {
 					fmt.Println("Keycloak is running")
 				} else {
 					fmt.Println("Keycloak is not running")
-					if docker.ContainerExists(kcName) {
+					if exists, _ := docker.ContainerExists(ctx, kcName); exists {
 						fmt.Println("Keycloak container exists")
 					} else {
 						fmt.Println("Keycloak container does not exist")
@@ -202,12 +221,54 @@ func CreateStatusCommand() *cobra.Command {
 	return cmd
 }
 
+// printComposeStatus lists the services of the compose environment with their
+// state and health.
+func printComposeStatus(ctx context.Context, cfg *config.Config) error {
+	env, err := docker.ResolveComposeEnv(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Compose environment:", env.Name, "("+env.Dir+")")
+	services, err := docker.ComposeServices(ctx, env.Dir)
+	if err != nil {
+		return err
+	}
+	if len(services) == 0 {
+		fmt.Println("No compose containers found; the environment is not started")
+		return nil
+	}
+	for _, s := range services {
+		health := s.Health
+		if health == "" {
+			health = "-"
+		}
+		fmt.Printf("  %-20s %-10s %-10s %s (%s)\n", s.Service, s.State, health, s.Container, s.Image)
+	}
+	return nil
+}
+
+// printContainerImage shows the image a container runs and warns when the
+// container differs from the configured image or settings.
+func printContainerImage(ctx context.Context, service, name, configured string, port int, settings config.ContainerSettings, required map[string]string) {
+	actual, err := docker.ContainerImage(ctx, name)
+	if err != nil {
+		fmt.Printf("%s image: %s (configured)\n", service, configured)
+		return
+	}
+	fmt.Printf("%s image: %s\n", service, actual)
+	drift, err := docker.ContainerDrift(ctx, name, configured, port, settings, required)
+	if err != nil || len(drift) == 0 {
+		return
+	}
+	fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  %s differs from the configuration: %s; run 'judo start --recreate' to recreate it\x1b[0m\n", name, strings.Join(drift, "; "))
+}
+
 func CreateDumpCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "dump",
 		Short: "Dump PostgreSQL DB data (creates <schema>_dump_YYYYMMDD_HHMMSS.tar.gz).",
 		Long:  help.DumpLongHelp(),
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			// Check if JUDO project is initialized
 			if err := requireJudoProject(); err != nil {
 				return err
@@ -220,14 +281,22 @@ func CreateDumpCommand() *cobra.Command {
 			}
 
 			// Ensure DB is up, then dump, then stop it (like the bash script)
-			docker.StartPostgres()
-			name := "postgres-" + cfg.SchemaName
-			file, err := db.DumpPostgresql(name, cfg.SchemaName)
+			ctx := cmd.Context()
+			if err := docker.StartPostgres(ctx); err != nil {
+				return err
+			}
+			// read after the start, which may generate or change the password
+			creds, err := cfg.Credentials()
+			if err != nil {
+				return err
+			}
+			name := cfg.Names().PostgresContainer
+			file, err := db.DumpPostgresql(ctx, name, cfg.SchemaName, cfg.Root(), creds)
 			if err != nil {
 				return err
 			}
 			fmt.Println("Database dumped to", file)
-			_ = docker.StopDockerInstance(name)
+			_ = docker.StopDockerInstance(ctx, name)
 			return nil
 		},
 	}
@@ -239,7 +308,7 @@ func CreateImportCommand() *cobra.Command {
 		Use:   "import",
 		Short: "Import PostgreSQL DB dump (pg_restore).",
 		Long:  help.ImportLongHelp(),
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			// Check if JUDO project is initialized
 			if err := requireJudoProject(); err != nil {
 				return err
@@ -251,20 +320,32 @@ func CreateImportCommand() *cobra.Command {
 				return nil
 			}
 
-			instance := "postgres-" + cfg.SchemaName
+			ctx := cmd.Context()
+			names := cfg.Names()
+			instance := names.PostgresContainer
 			// Fresh db state
-			_ = docker.RemoveDockerInstance(instance)
-			_ = docker.RemoveDockerVolume(cfg.SchemaName + "_postgresql_db")
-			_ = docker.RemoveDockerVolume(cfg.SchemaName + "_postgresql_data")
+			_ = docker.RemoveDockerInstance(ctx, instance)
+			for _, v := range names.PostgresVolumes() {
+				_ = docker.RemoveDockerVolume(ctx, v)
+			}
 
 			// Start DB and wait
-			docker.StartPostgres()
+			if err := docker.StartPostgres(ctx); err != nil {
+				return err
+			}
+			creds, err := cfg.Credentials()
+			if err != nil {
+				return err
+			}
 
 			// Determine dump file
 			dumpFile := config.Options.DumpName
+			if dumpFile != "" && !filepath.IsAbs(dumpFile) && !utils.FileExists(dumpFile) {
+				// relative names are looked up in the project root as well
+				dumpFile = filepath.Join(cfg.Root(), dumpFile)
+			}
 			if strings.TrimSpace(dumpFile) == "" {
-				var err error
-				dumpFile, err = db.FindLatestDump(cfg.SchemaName)
+				dumpFile, err = db.FindLatestDump(cfg.Root(), cfg.SchemaName)
 				if err != nil {
 					return err
 				}
@@ -272,14 +353,13 @@ func CreateImportCommand() *cobra.Command {
 			fmt.Println("Loading dump:", dumpFile)
 
 			// Run pg_restore inside the container
-			if err := db.ImportPostgresql(instance, cfg.SchemaName, dumpFile); err != nil {
+			if err := db.ImportPostgresql(ctx, instance, cfg.SchemaName, dumpFile, creds); err != nil {
 				return err
 			}
 
 			// Bounce container (same as bash)
-			_ = docker.StopDockerInstance(instance)
-			docker.StartPostgres()
-			return nil
+			_ = docker.StopDockerInstance(ctx, instance)
+			return docker.StartPostgres(ctx)
 		},
 	}
 	// Bash used -dn / --dump-name; we expose -n/--dump-name here.
@@ -292,7 +372,7 @@ func CreateSchemaUpgradeCommand() *cobra.Command {
 		Use:   "schema-upgrade",
 		Short: "Apply RDBMS schema upgrade using current running database (PostgreSQL only).",
 		Long:  help.SchemaUpgradeLongHelp(),
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			// Check if JUDO project is initialized
 			if err := requireJudoProject(); err != nil {
 				return err
@@ -304,61 +384,148 @@ func CreateSchemaUpgradeCommand() *cobra.Command {
 				return nil
 			}
 
-			// Ensure Postgres is started and reachable
-			docker.StartPostgres()
+			// Ensure Postgres is started and reachable; credentials are read
+			// afterwards as an existing volume may keep its earlier password
+			if err := docker.StartPostgres(cmd.Context()); err != nil {
+				return err
+			}
 
-			updateModel := filepath.Join(cfg.ModelDir, "model", "target", "generated-resources", "model",
-				fmt.Sprintf("%s-rdbms_postgresql.model", cfg.SchemaName))
-			schemaDir := filepath.Join(cfg.ModelDir, "schema")
+			creds, err := cfg.Credentials()
+			if err != nil {
+				return err
+			}
 
-			args := []string{
-				"judo-rdbms-schema:apply",
-				fmt.Sprintf("-DjdbcUrl=jdbc:postgresql://127.0.0.1:%d/%s", cfg.PostgresPort, cfg.SchemaName),
-				"-DdbType=postgresql",
-				"-DdbUser=" + cfg.SchemaName,
-				"-DdbPassword=" + cfg.SchemaName,
-				"-DschemaIgnoreModelDependency=true",
-				"-DupdateModel=" + updateModel,
-				"-f", schemaDir,
+			settings, err := writeSchemaSettings(creds.DBPassword, mavenGlobalSettings())
+			if err != nil {
+				return err
 			}
-			return utils.Run("mvnd", args...)
+			defer os.Remove(settings)
+
+			return utils.Run("mvnd", schemaUpgradeArgs(cfg, creds, settings)...)
 		},
 	}
 	return cmd
 }
 
-func CreateCleanCommand() *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "clean",
-		Short: "Stop postgresql docker container and clear data.",
-		Long:  help.CleanLongHelp(),
-		RunE: func(_ *cobra.Command, args []string) error {
-			// Check if JUDO project is initialized
-			if err := requireJudoProject(); err != nil {
-				return err
-			}
-			cfg := config.GetConfig()
-			for _, env := range docker.GetComposeEnvs(cfg) {
-				_ = docker.StopCompose(cfg, env)
-			}
-			_ = docker.RemoveDockerInstance("postgres-" + cfg.SchemaName)
-			_ = docker.RemoveDockerInstance("keycloak-" + cfg.KeycloakName)
-			_ = docker.RemoveDockerNetwork(cfg.AppName)
-			_ = docker.RemoveDockerVolume(cfg.AppName + "_certs")
-			_ = docker.RemoveDockerVolume(cfg.SchemaName + "_postgresql_db")
-			_ = docker.RemoveDockerVolume(cfg.SchemaName + "_postgresql_data")
-			_ = docker.RemoveDockerVolume(cfg.AppName + "_filestore")
-			if cfg.Runtime == "karaf" {
-				karaf.StopKaraf(cfg.KarafDir)
-				if err := os.RemoveAll(cfg.KarafDir); err != nil {
-					// Return the error so the user sees it.
-					return fmt.Errorf("failed to remove karaf directory: %w", err)
-				}
-			}
+// schemaUpgradeArgs builds the Maven arguments of schema-upgrade. The
+// database password is not among them: it comes from the settings file so it
+// does not show up in the process list.
+func schemaUpgradeArgs(cfg *config.Config, creds config.Credentials, settings string) []string {
+	updateModel := filepath.Join(cfg.ModelDir, "model", "target", "generated-resources", "model",
+		fmt.Sprintf("%s-rdbms_postgresql.model", cfg.SchemaName))
+	return []string{
+		"judo-rdbms-schema:apply",
+		"-gs", settings,
+		fmt.Sprintf("-DjdbcUrl=jdbc:postgresql://127.0.0.1:%d/%s", cfg.PostgresPort, cfg.SchemaName),
+		"-DdbType=postgresql",
+		"-DdbUser=" + creds.DBUser,
+		"-DschemaIgnoreModelDependency=true",
+		"-DupdateModel=" + updateModel,
+		"-f", filepath.Join(cfg.ModelDir, "schema"),
+	}
+}
+
+// schemaProfile is the Maven settings profile that sets the dbPassword
+// property of the schema-upgrade build.
+const schemaProfile = `    <profile>
+      <id>judo-schema-upgrade</id>
+      <properties>
+        <dbPassword>%s</dbPassword>
+      </properties>
+    </profile>
+`
+
+// writeSchemaSettings writes a Maven settings file, readable by the owner
+// only, whose active profile sets the dbPassword property of the build. It
+// replaces the global settings given with -gs, so the profile is added to a
+// copy of the existing global settings to keep their mirrors and proxies.
+// The caller removes it when Maven is done.
+func writeSchemaSettings(password, global string) (string, error) {
+	var escaped strings.Builder
+	if err := xml.EscapeText(&escaped, []byte(password)); err != nil {
+		return "", err
+	}
+	base := []byte("<settings>\n</settings>\n")
+	if global != "" {
+		data, err := os.ReadFile(global)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the Maven global settings: %w", err)
+		}
+		base = data
+	}
+	merged, err := mergeSchemaProfile(base, fmt.Sprintf(schemaProfile, escaped.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to extend the Maven global settings %s: %w", global, err)
+	}
+	f, err := os.CreateTemp("", "judo-schema-settings-*.xml")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(merged)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+var xmlComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// mergeSchemaProfile adds profile to the profiles of a settings document and
+// activates it, creating the profiles and activeProfiles elements when the
+// document has none. Commented-out elements, which the default settings.xml
+// is full of, are not mistaken for real ones.
+func mergeSchemaProfile(settings []byte, profile string) ([]byte, error) {
+	doc := string(settings)
+	insert := func(closing, opening, content string) error {
+		// comments are blanked out so only live elements are found
+		live := xmlComment.ReplaceAllStringFunc(doc, func(c string) string { return strings.Repeat(" ", len(c)) })
+		if i := strings.LastIndex(live, closing); i >= 0 {
+			doc = doc[:i] + content + doc[i:]
 			return nil
-		},
+		}
+		i := strings.LastIndex(live, "</settings>")
+		if i < 0 {
+			return errors.New("no </settings> element")
+		}
+		doc = doc[:i] + "  " + opening + "\n" + content + "  " + closing + "\n" + doc[i:]
+		return nil
 	}
-	return cmd
+	if err := insert("</profiles>", "<profiles>", profile); err != nil {
+		return nil, err
+	}
+	if err := insert("</activeProfiles>", "<activeProfiles>", "    <activeProfile>judo-schema-upgrade</activeProfile>\n"); err != nil {
+		return nil, err
+	}
+	return []byte(doc), nil
+}
+
+// mavenGlobalSettings locates conf/settings.xml of the Maven installation
+// mvnd runs, or returns "" when there is none.
+func mavenGlobalSettings() string {
+	var candidates []string
+	if home := os.Getenv("MVND_HOME"); home != "" {
+		candidates = append(candidates, filepath.Join(home, "mvn", "conf", "settings.xml"))
+	}
+	if path, err := exec.LookPath("mvnd"); err == nil {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			candidates = append(candidates, filepath.Join(filepath.Dir(resolved), "..", "mvn", "conf", "settings.xml"))
+		}
+	}
+	for _, env := range []string{"MAVEN_HOME", "M2_HOME"} {
+		if home := os.Getenv(env); home != "" {
+			candidates = append(candidates, filepath.Join(home, "conf", "settings.xml"))
+		}
+	}
+	for _, c := range candidates {
+		if utils.FileExists(c) {
+			return filepath.Clean(c)
+		}
+	}
+	return ""
 }
 
 func CreatePruneCommand() *cobra.Command {
@@ -375,7 +542,7 @@ func CreatePruneCommand() *cobra.Command {
 			}
 			cfg := config.GetConfig()
 			st := &config.State{PruneFrontend: frontend, PruneConfirm: !yes}
-			pruneApplication(cfg, st)
+			pruneApplication(cmd.Context(), cfg, st)
 			return nil
 		},
 	}
@@ -422,6 +589,7 @@ func CreateUpdateCommand() *cobra.Command {
 }
 
 func CreateStopCommand() *cobra.Command {
+	var volumes bool
 	cmd := &cobra.Command{
 		Use:   "stop",
 		Short: "Stop application, postgresql and keycloak (if running)",
@@ -432,23 +600,31 @@ func CreateStopCommand() *cobra.Command {
 				return err
 			}
 			cfg := config.GetConfig()
+			if cfg.Runtime == "compose" {
+				env, err := docker.ResolveComposeEnv(cfg)
+				if err != nil {
+					return err
+				}
+				return docker.ComposeDown(cmd.Context(), env.Dir, volumes)
+			}
 			if cfg.Runtime == "karaf" {
 				karaf.StopKaraf(cfg.KarafDir)
 				if cfg.DBType == "postgresql" {
-					_ = docker.StopDockerInstance("postgres-" + cfg.SchemaName)
+					_ = docker.StopDockerInstance(cmd.Context(), cfg.Names().PostgresContainer)
 				}
-				_ = docker.StopDockerInstance("keycloak-" + cfg.KeycloakName)
+				_ = docker.StopDockerInstance(cmd.Context(), cfg.Names().KeycloakContainer)
 			}
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&volumes, "volumes", false, "Also remove the named volumes of the compose environment")
 	return cmd
 }
 
-func runBuild(cmd *cobra.Command, args []string) {
+func runBuild(cmd *cobra.Command, args []string) error {
 	// Check if JUDO project is initialized
 	if err := requireJudoProject(); err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	// reflect skip-* flags into options
@@ -474,7 +650,9 @@ func runBuild(cmd *cobra.Command, args []string) {
 	cfg := config.GetConfig()
 	if config.Options.Reckless {
 		// mirror bash: start local env first
-		startLocalEnvironment()
+		if err := startLocalEnvironment(cmd.Context()); err != nil {
+			return err
+		}
 	}
 
 	// stop mvnd daemon as in bash (except reckless path which may run fast)
@@ -535,7 +713,10 @@ func runBuild(cmd *cobra.Command, args []string) {
 		buildArgs = append(buildArgs, "-DskipSchemaCli")
 	}
 
-	// extra user maven args (best-effort split)
+	// extra user maven args (best-effort split): maven_args from config, then --maven-argument
+	if s := strings.TrimSpace(cfg.MavenArgs); s != "" {
+		buildArgs = append(buildArgs, strings.Fields(s)...)
+	}
 	if s := strings.TrimSpace(config.Options.ExtraMavenArgs); s != "" {
 		buildArgs = append(buildArgs, strings.Fields(s)...)
 	}
@@ -547,22 +728,22 @@ func runBuild(cmd *cobra.Command, args []string) {
 		fmt.Println("Building backend app module only...")
 		args := append([]string{}, buildArgs...)
 		args = append(args, "-f", cfg.AppDir, "-pl", "app,interceptors", "-DskipModels=true")
-		utils.CheckError(utils.Run("mvnd", args...))
-		return
+		return utils.Run("mvnd", args...)
 
 	case !config.Options.BuildBackend && config.Options.BuildFrontend:
 		// frontend only
 		fmt.Println("Building frontend only...")
 		args := append([]string{}, buildArgs...)
 		args = append(args, "-f", filepath.Join(cfg.AppDir, "frontend-react"))
-		utils.CheckError(utils.Run("mvnd", args...))
-		return
+		return utils.Run("mvnd", args...)
 
 	default:
 		// full (or mostly-full) build, starting at MODEL_DIR
 		args := append([]string{}, buildArgs...)
 		args = append(args, "-f", cfg.ModelDir)
-		utils.CheckError(utils.Run("mvnd", args...))
+		if err := utils.Run("mvnd", args...); err != nil {
+			return err
+		}
 	}
 
 	// Reckless extras: optionally (light) post-steps
@@ -570,6 +751,7 @@ func runBuild(cmd *cobra.Command, args []string) {
 		// Skipping schema upgrade + bundle hot-install here to keep it simple and stable.
 		fmt.Println("Reckless build completed.")
 	}
+	return nil
 }
 
 func CreateStartCommand() *cobra.Command {
@@ -577,24 +759,27 @@ func CreateStartCommand() *cobra.Command {
 		Use:   "start",
 		Short: "Start application",
 		Long:  help.StartLongHelp(),
-		Run:   runStart,
+		RunE:  runStart,
 	}
 	cmd.Flags().Bool("skip-keycloak", false, "Skip starting Keycloak")
 	cmd.Flags().Bool("skip-watch-bundles", false, "Disable watching of bundle changes")
+	cmd.Flags().BoolVar(&config.Options.Recreate, "recreate", false, "Recreate containers whose image or settings differ from the configuration")
+	cmd.Flags().Bool("auto-ports", false, "Move Karaf, Keycloak and PostgreSQL to free ports when theirs are taken and remember them")
 	cmd.Flags().String("options", "", "Additional options: key=value,key2=value2 (e.g. runtime=compose,dbtype=postgresql,karaf_port=8181)")
 	return cmd
 }
 
-func runStart(cmd *cobra.Command, _ []string) {
+func runStart(cmd *cobra.Command, _ []string) error {
 	// Check if JUDO project is initialized
 	if err := requireJudoProject(); err != nil {
-		log.Fatal(err)
+		return err
 	}
 
+	ctx := cmd.Context()
 	cfg := config.GetConfig()
 	// Pre-flight checks
-	if !docker.IsDockerRunning() {
-		log.Fatal("Docker daemon is not running. Please start Docker and try again.")
+	if !docker.IsDockerRunning(ctx) {
+		return fmt.Errorf("%w; please start Docker and try again", docker.ErrDaemonUnreachable)
 	}
 
 	// Set default values
@@ -612,87 +797,196 @@ func runStart(cmd *cobra.Command, _ []string) {
 
 	// parse -o/--options: key=value,key2=value2
 	if raw, _ := cmd.Flags().GetString("options"); strings.TrimSpace(raw) != "" {
-		config.ApplyInlineOptions(raw)
+		if err := config.ApplyInlineOptions(raw); err != nil {
+			return err
+		}
+	}
+
+	runtime := cfg.Runtime
+	if runtime != "compose" && runtime != "karaf" {
+		fmt.Println("Unknown runtime:", runtime, " — defaulting to karaf")
+		runtime = "karaf"
+	}
+
+	if v, _ := cmd.Flags().GetBool("auto-ports"); v {
+		recreate, err := allocatePorts(ctx, cfg, runtime)
+		if err != nil {
+			return err
+		}
+		// only the containers publishing a moved port are recreated
+		ctx = docker.WithRecreate(ctx, recreate...)
 	}
 
 	// Port checks with warnings instead of errors
 	if config.Options.StartKeycloak {
 		if !utils.IsPortAvailable(cfg.KeycloakPort) {
 			// Check if this is our own Keycloak instance using the port
-			if docker.IsPortUsedByKeycloak(cfg.KeycloakPort) {
-				fmt.Printf("\x1b[33m⚠️  Keycloak port %d is already in use by your running JUDO Keycloak instance. Skipping Keycloak start.\x1b[0m\n", cfg.KeycloakPort)
+			if docker.IsPortUsedByKeycloak(ctx, cfg.KeycloakPort) {
+				fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  Keycloak port %d is already in use by your running JUDO Keycloak instance. Skipping Keycloak start.\x1b[0m\n", cfg.KeycloakPort)
 				config.Options.StartKeycloak = false // Skip Keycloak start
 			} else {
-				log.Fatalf("Keycloak port %d is already in use by another process.", cfg.KeycloakPort)
+				return &utils.PortInUseError{Service: "Keycloak", Port: cfg.KeycloakPort}
 			}
 		}
 	}
 	if cfg.DBType == "postgresql" {
 		if !utils.IsPortAvailable(cfg.PostgresPort) {
 			// Check if this is our own PostgreSQL instance using the port
-			if docker.IsPortUsedByPostgres(cfg.PostgresPort) {
-				fmt.Printf("\x1b[33m⚠️  PostgreSQL port %d is already in use by your running JUDO PostgreSQL instance. Skipping PostgreSQL start.\x1b[0m\n", cfg.PostgresPort)
+			if docker.IsPortUsedByPostgres(ctx, cfg.PostgresPort) {
+				fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  PostgreSQL port %d is already in use by your running JUDO PostgreSQL instance. Skipping PostgreSQL start.\x1b[0m\n", cfg.PostgresPort)
 				// We'll skip PostgreSQL start by not calling docker.StartPostgres() later
 			} else {
-				log.Fatalf("PostgreSQL port %d is already in use by another process.", cfg.PostgresPort)
+				return &utils.PortInUseError{Service: "PostgreSQL", Port: cfg.PostgresPort}
 			}
 		}
 	}
 
-	runtime := cfg.Runtime
-	if runtime != "compose" && runtime != "karaf" {
-		fmt.Println("Unknown runtime:", runtime, " — defaulting to karaf")
-		runtime = "karaf"
+	var composeEnv docker.ComposeEnv
+	if runtime == "compose" {
+		env, err := docker.ResolveComposeEnv(cfg)
+		if err != nil {
+			return err
+		}
+		composeEnv = env
 	}
 
 	if runtime == "karaf" {
 		// Karaf-specific checks
 		if !utils.IsPortAvailable(cfg.KarafPort) {
 			// Check if this is our own Karaf instance using the port
-			karafDir := filepath.Join(cfg.ModelDir, "application", ".karaf")
-			if utils.IsPortUsedByKaraf(cfg.KarafPort, karafDir) {
-				fmt.Printf("\x1b[33m⚠️  Karaf port %d is already in use by your running JUDO application. Skipping Karaf start.\x1b[0m\n", cfg.KarafPort)
+			if utils.IsPortUsedByKaraf(cfg.KarafPort, cfg.KarafDir) {
+				fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  Karaf port %d is already in use by your running JUDO application. Skipping Karaf start.\x1b[0m\n", cfg.KarafPort)
 				// Skip Karaf start by not calling karaf.StartKaraf() later
 				config.Options.StartKaraf = false
 				config.Options.WatchBundles = false // Also disable bundle watching
 			} else {
-				log.Fatalf("Karaf port %d is already in use by another process.", cfg.KarafPort)
+				return &utils.PortInUseError{Service: "Karaf", Port: cfg.KarafPort}
 			}
 		}
-		ver := utils.GetProjectVersion()
-		tarPath := filepath.Join(cfg.ModelDir, "application", "karaf-offline", "target",
+		ver := utils.GetProjectVersion(cfg.ModelDir)
+		tarPath := filepath.Join(cfg.AppDir, "karaf-offline", "target",
 			fmt.Sprintf("%s-application-karaf-offline-%s.tar.gz", cfg.AppName, ver),
 		)
 		if _, err := os.Stat(tarPath); os.IsNotExist(err) {
-			log.Fatalf("Karaf archive not found at %s. Please run a build first.", tarPath)
+			return fmt.Errorf("Karaf archive not found at %s. Please run a build first", tarPath)
 		}
 	}
 
+	if runtime == "karaf" {
+		printServiceURLs(cfg)
+	}
+
 	// Execution
-	switch runtime {
-	case "compose":
-		docker.StartCompose()
-	case "karaf":
-		startLocalEnvironment()
+	if runtime == "compose" {
+		return docker.StartCompose(ctx, composeEnv)
+	}
+	return startLocalEnvironment(ctx)
+}
+
+// allocatePorts moves every service whose port is taken by another process to
+// the next free port and records the ports in the state file, so later
+// commands of the profile use them too. It returns the existing containers
+// that still publish the old port and have to be recreated.
+func allocatePorts(ctx context.Context, cfg *config.Config, runtime string) ([]string, error) {
+	services := []struct {
+		key, name, container string
+		port                 int
+		needed               bool
+		ours                 func(port int) bool
+	}{
+		{"postgres_port", "PostgreSQL", cfg.Names().PostgresContainer, cfg.PostgresPort, cfg.DBType == "postgresql", func(port int) bool { return docker.IsPortUsedByPostgres(ctx, port) }},
+		{"keycloak_port", "Keycloak", cfg.Names().KeycloakContainer, cfg.KeycloakPort, config.Options.StartKeycloak, func(port int) bool { return docker.IsPortUsedByKeycloak(ctx, port) }},
+		{"karaf_port", "Karaf", "", cfg.KarafPort, runtime == "karaf", func(port int) bool { return utils.IsPortUsedByKaraf(port, cfg.KarafDir) }},
+	}
+
+	taken := map[int]bool{}
+	for _, s := range services {
+		taken[s.port] = true
+	}
+	var moved, recreate []string
+	for _, s := range services {
+		if !s.needed || utils.IsPortAvailable(s.port) || s.ours(s.port) {
+			continue
+		}
+		port, ok := utils.NextFreePort(s.port+1, 100, taken)
+		if !ok {
+			return nil, fmt.Errorf("%w and no free port was found up to %d", &utils.PortInUseError{Service: s.name, Port: s.port}, s.port+100)
+		}
+		fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  %s port %d is in use by another process, using %d\x1b[0m\n", s.name, s.port, port)
+		if err := cfg.SetPort(s.key, port); err != nil {
+			return nil, err
+		}
+		moved = append(moved, s.key)
+		taken[port] = true
+		if s.container == "" {
+			continue
+		}
+		if exists, err := docker.ContainerExists(ctx, s.container); err != nil {
+			return nil, err
+		} else if exists {
+			// the existing container publishes the old port
+			recreate = append(recreate, s.container)
+		}
+	}
+	if err := cfg.SavePorts(moved...); err != nil {
+		return nil, fmt.Errorf("failed to save the chosen ports: %w", err)
+	}
+	return recreate, nil
+}
+
+// printServiceURLs shows where the services of the karaf runtime listen.
+func printServiceURLs(cfg *config.Config) {
+	fmt.Fprintf(utils.Stdout, "\x1b[1;36mKaraf:\x1b[0m      http://localhost:%d/\n", cfg.KarafPort)
+	if config.Options.StartKeycloak {
+		fmt.Fprintf(utils.Stdout, "\x1b[1;36mKeycloak:\x1b[0m   http://localhost:%d/auth\n", cfg.KeycloakPort)
+	}
+	if cfg.DBType == "postgresql" {
+		fmt.Fprintf(utils.Stdout, "\x1b[1;36mPostgreSQL:\x1b[0m localhost:%d/%s\n", cfg.PostgresPort, cfg.SchemaName)
 	}
 }
 
-func startLocalEnvironment() {
+// startLocalEnvironment starts the services of the karaf runtime in order,
+// each one only after the previous one is ready. When ctx is cancelled
+// (Ctrl-C) before everything is up, the containers, networks, volumes and
+// processes created so far are removed again.
+func startLocalEnvironment(ctx context.Context) error {
+	journal := &rollback.Journal{}
+	err := startServices(rollback.WithJournal(ctx, journal))
+	if err == nil || ctx.Err() == nil || journal.Len() == 0 {
+		return err
+	}
+	fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  Start interrupted, rolling back...\x1b[0m\n")
+	// ctx is cancelled already, the cleanup gets its own deadline
+	cleanupCtx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	if rbErr := journal.Run(cleanupCtx, os.Stdout); rbErr != nil {
+		return fmt.Errorf("%w\nrollback incomplete: %v", err, rbErr)
+	}
+	fmt.Fprintf(utils.Stdout, "\x1b[32m✅ Rolled back\x1b[0m\n")
+	return err
+}
+
+func startServices(ctx context.Context) error {
 	cfg := config.GetConfig()
 	if cfg.DBType == "postgresql" {
-		docker.StartPostgres()
+		if err := docker.StartPostgres(ctx); err != nil {
+			return err
+		}
 	}
 
 	if config.Options.StartKeycloak {
-		docker.StartKeycloak()
+		if err := docker.StartKeycloak(ctx); err != nil {
+			return err
+		}
 	}
 
 	if config.Options.StartKaraf {
-		karaf.StartKaraf()
+		return karaf.StartKaraf(ctx)
 	}
+	return nil
 }
 
-func pruneApplication(cfg *config.Config, st *config.State) {
+func pruneApplication(ctx context.Context, cfg *config.Config, st *config.State) {
 	canContinue := "Y"
 	if st.PruneConfirm {
 		location := "this repository"
@@ -716,9 +1010,9 @@ func pruneApplication(cfg *config.Config, st *config.State) {
 	}
 
 	if cfg.DBType == "postgresql" {
-		_ = docker.StopDockerInstance("postgres-" + cfg.SchemaName)
+		_ = docker.StopDockerInstance(ctx, cfg.Names().PostgresContainer)
 	}
-	_ = docker.StopDockerInstance("keycloak-" + cfg.KeycloakName)
+	_ = docker.StopDockerInstance(ctx, cfg.Names().KeycloakContainer)
 	if cfg.Runtime == "karaf" {
 		karaf.StopKaraf(cfg.KarafDir)
 	}
@@ -756,7 +1050,7 @@ func doctorMessage(emoji, color, message string) {
 	default:
 		colorCode = "\x1b[0m" // Reset
 	}
-	fmt.Printf("%s%s %s\x1b[0m\n", emoji, colorCode, message)
+	fmt.Fprintf(utils.Stdout, "%s%s %s\x1b[0m\n", emoji, colorCode, message)
 }
 
 func runDoctor(verbose bool) error {
@@ -819,16 +1113,16 @@ func runDoctor(verbose bool) error {
 		doctorMessage("⚠️ ", "yellow", "SDKMAN: Not found - installing now...")
 
 		// Always install SDKMAN automatically (no prompt)
-		fmt.Printf("   \x1b[33mInstalling SDKMAN...\x1b[0m\n")
+		fmt.Fprintf(utils.Stdout, "   \x1b[33mInstalling SDKMAN...\x1b[0m\n")
 		if err := utils.InstallSDKMAN(); err != nil {
-			fmt.Printf("   \x1b[31m❌ Failed to install SDKMAN: %v\x1b[0m\n", err)
+			fmt.Fprintf(utils.Stdout, "   \x1b[31m❌ Failed to install SDKMAN: %v\x1b[0m\n", err)
 			if verbose {
 				if utils.HaveWSL() {
-					fmt.Printf("   \x1b[33mOn Windows, WSL is required for SDKMAN installation\x1b[0m\n")
+					fmt.Fprintf(utils.Stdout, "   \x1b[33mOn Windows, WSL is required for SDKMAN installation\x1b[0m\n")
 				}
 			}
 		} else {
-			fmt.Printf("   \x1b[32m✅ SDKMAN installed successfully\x1b[0m\n")
+			fmt.Fprintf(utils.Stdout, "   \x1b[32m✅ SDKMAN installed successfully\x1b[0m\n")
 			sdkmanAvailable = true
 			// SDKMAN is now available, so mark this check as passed
 			allPassed = true
@@ -851,22 +1145,22 @@ func runDoctor(verbose bool) error {
 		// If SDKMAN is available and we're in a JUDO project, install required tools
 		if sdkmanAvailable {
 			fmt.Println()
-			fmt.Printf("\x1b[1;33m🔧 Installing required development tools via SDKMAN...\x1b[0m\n")
+			fmt.Fprintf(utils.Stdout, "\x1b[1;33m🔧 Installing required development tools via SDKMAN...\x1b[0m\n")
 			if err := utils.InstallRequiredTools(); err != nil {
-				fmt.Printf("\x1b[33m⚠️  Failed to install required tools: %v\x1b[0m\n", err)
+				fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  Failed to install required tools: %v\x1b[0m\n", err)
 				if verbose {
-					fmt.Printf("   \x1b[33mYou can manually install tools using 'sdk install maven' and 'sdk install java'\x1b[0m\n")
+					fmt.Fprintf(utils.Stdout, "   \x1b[33mYou can manually install tools using 'sdk install maven' and 'sdk install java'\x1b[0m\n")
 				}
 			} else {
-				fmt.Printf("\x1b[32m✅ Development tools installed successfully\x1b[0m\n")
+				fmt.Fprintf(utils.Stdout, "\x1b[32m✅ Development tools installed successfully\x1b[0m\n")
 			}
 		} else if verbose {
-			fmt.Printf("   \x1b[33mSDKMAN not available - cannot auto-install Maven/Java\x1b[0m\n")
+			fmt.Fprintf(utils.Stdout, "   \x1b[33mSDKMAN not available - cannot auto-install Maven/Java\x1b[0m\n")
 		}
 	} else {
 		doctorMessage("ℹ️ ", "blue", "JUDO Project: Not initialized in this directory")
 		if verbose {
-			fmt.Printf("   \x1b[36mRun 'judo init' to initialize a new JUDO project\x1b[0m\n")
+			fmt.Fprintf(utils.Stdout, "   \x1b[36mRun 'judo init' to initialize a new JUDO project\x1b[0m\n")
 		}
 	}
 
@@ -890,25 +1184,20 @@ func checkGo(verbose bool) bool {
 }
 
 func checkDocker(verbose bool) bool {
-	// Check if docker command exists
-	version, err := utils.RunCapture("docker", "--version")
-	if err != nil {
-		return false
-	}
-	if verbose {
-		fmt.Printf("   Docker version: %s\n", version)
-	}
-
-	// Check if docker daemon is running
-	_, err = utils.RunCapture("docker", "info")
-	if err != nil {
+	// The daemon is probed through the same discovery the CLI uses, so
+	// rootless Docker, Podman and docker contexts count as well.
+	engine, ok := docker.ActiveEngine()
+	if !ok || !docker.IsDockerRunning(context.Background()) {
 		if verbose {
 			fmt.Printf("   Docker daemon status: Not running\n")
 		}
 		return false
 	}
 	if verbose {
-		fmt.Printf("   Docker daemon status: Running\n")
+		if version, err := utils.RunCapture("docker", "--version"); err == nil {
+			fmt.Printf("   Docker version: %s\n", version)
+		}
+		fmt.Printf("   Docker daemon status: Running (%s, %s)\n", engine.Name, engine.Host)
 	}
 	return true
 }
@@ -989,7 +1278,7 @@ func checkSDKMAN(verbose bool) bool {
 
 func checkPortAvailability(port int, service string, verbose bool) {
 	if utils.IsPortAvailable(port) {
-		fmt.Printf("\x1b[32m✅ Port %d (%s): Available\x1b[0m\n", port, service)
+		fmt.Fprintf(utils.Stdout, "\x1b[32m✅ Port %d (%s): Available\x1b[0m\n", port, service)
 	} else {
 		// Check if this is a JUDO project and if our services are using the port
 		cfg := config.GetConfig()
@@ -999,40 +1288,39 @@ func checkPortAvailability(port int, service string, verbose bool) {
 
 		if config.IsProjectInitialized() {
 			if cfg.Runtime == "karaf" {
-				karafDir := filepath.Join(cfg.ModelDir, "application", ".karaf")
-				karafUsingPort = utils.IsPortUsedByKaraf(port, karafDir)
+				karafUsingPort = utils.IsPortUsedByKaraf(port, cfg.KarafDir)
 			}
 
 			// Check if PostgreSQL is using the port (for port 5432)
 			if port == 5432 && cfg.DBType == "postgresql" {
-				postgresUsingPort = docker.IsPortUsedByPostgres(port)
+				postgresUsingPort = docker.IsPortUsedByPostgres(context.Background(), port)
 			}
 
 			// Check if Keycloak is using the port (for port 8080)
 			if port == 8080 {
-				keycloakUsingPort = docker.IsPortUsedByKeycloak(port)
+				keycloakUsingPort = docker.IsPortUsedByKeycloak(context.Background(), port)
 			}
 		}
 
 		if karafUsingPort {
-			fmt.Printf("\x1b[33m⚠️  Port %d (%s): In use by current Karaf instance\x1b[0m\n", port, service)
+			fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  Port %d (%s): In use by current Karaf instance\x1b[0m\n", port, service)
 			if verbose {
-				fmt.Printf("   \x1b[33mNote: This port is used by your running JUDO application\x1b[0m\n")
+				fmt.Fprintf(utils.Stdout, "   \x1b[33mNote: This port is used by your running JUDO application\x1b[0m\n")
 			}
 		} else if postgresUsingPort {
-			fmt.Printf("\x1b[33m⚠️  Port %d (%s): In use by current PostgreSQL instance\x1b[0m\n", port, service)
+			fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  Port %d (%s): In use by current PostgreSQL instance\x1b[0m\n", port, service)
 			if verbose {
-				fmt.Printf("   \x1b[33mNote: This port is used by your running JUDO PostgreSQL database\x1b[0m\n")
+				fmt.Fprintf(utils.Stdout, "   \x1b[33mNote: This port is used by your running JUDO PostgreSQL database\x1b[0m\n")
 			}
 		} else if keycloakUsingPort {
-			fmt.Printf("\x1b[33m⚠️  Port %d (%s): In use by current Keycloak instance\x1b[0m\n", port, service)
+			fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  Port %d (%s): In use by current Keycloak instance\x1b[0m\n", port, service)
 			if verbose {
-				fmt.Printf("   \x1b[33mNote: This port is used by your running JUDO Keycloak instance\x1b[0m\n")
+				fmt.Fprintf(utils.Stdout, "   \x1b[33mNote: This port is used by your running JUDO Keycloak instance\x1b[0m\n")
 			}
 		} else {
-			fmt.Printf("\x1b[31m❌ Port %d (%s): In use by another process\x1b[0m\n", port, service)
+			fmt.Fprintf(utils.Stdout, "\x1b[31m❌ Port %d (%s): In use by another process\x1b[0m\n", port, service)
 			if verbose {
-				fmt.Printf("   \x1b[31mWarning: This port is occupied by another application, which will cause conflicts\x1b[0m\n")
+				fmt.Fprintf(utils.Stdout, "   \x1b[31mWarning: This port is occupied by another application, which will cause conflicts\x1b[0m\n")
 			}
 		}
 	}
@@ -1041,7 +1329,7 @@ func checkPortAvailability(port int, service string, verbose bool) {
 // requireJudoProject checks if a JUDO project is initialized and returns an error if not
 func requireJudoProject() error {
 	if !config.IsProjectInitialized() {
-		return fmt.Errorf("no JUDO project initialized in this directory\nRun 'judo init' to initialize a new JUDO project")
+		return fmt.Errorf("no JUDO project found in this directory or any parent directory\nRun 'judo init' to initialize a new JUDO project, or use -C <dir>")
 	}
 	return nil
 }
@@ -1052,9 +1340,9 @@ func CreateLogCommand() *cobra.Command {
 	var lines int
 
 	cmd := &cobra.Command{
-		Use:   "log",
-		Short: "Display or tail Karaf console log",
-		Long:  "Display the contents of the Karaf console.out log file with optional tailing and following",
+		Use:   "log [service...]",
+		Short: "Display or tail Karaf console log or compose service logs",
+		Long:  help.LogLongHelp(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Check if JUDO project is initialized
 			if err := requireJudoProject(); err != nil {
@@ -1063,8 +1351,19 @@ func CreateLogCommand() *cobra.Command {
 
 			cfg := config.GetConfig()
 
-			if cfg.Runtime != "karaf" {
-				return fmt.Errorf("log command is only supported for karaf runtime")
+			if cfg.Runtime == "compose" {
+				n := "all"
+				if (tail || follow) && lines > 0 {
+					n = fmt.Sprint(lines)
+				}
+				env, err := docker.ResolveComposeEnv(cfg)
+				if err != nil {
+					return err
+				}
+				return docker.ComposeLogs(cmd.Context(), env.Dir, args, n, follow, utils.Stdout)
+			}
+			if len(args) > 0 {
+				return fmt.Errorf("services can only be selected with the compose runtime")
 			}
 
 			logFile := filepath.Join(cfg.KarafDir, "console.out")
@@ -1114,9 +1413,9 @@ func CreateInitCommand() *cobra.Command {
 		Short: "Initialize a new JUDO project.",
 		Long:  "This command initializes a new JUDO project by checking for existing configuration files and, if necessary, generating a new project structure using Maven.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cwd, err := os.Getwd()
-			if err != nil {
-				return fmt.Errorf("failed to get current working directory: %w", err)
+			cwd := config.StartDir()
+			if cwd == "" {
+				return fmt.Errorf("failed to get current working directory")
 			}
 
 			judoVersionPropsPath := filepath.Join(cwd, "judo-version.properties")
@@ -1198,7 +1497,7 @@ func CreateInitCommand() *cobra.Command {
 			}
 
 			fmt.Printf("Executing Maven command: %s %s\n", mavenCommand, strings.Join(mavenArgs, " "))
-			return utils.Run(mavenCommand, mavenArgs...)
+			return utils.RunInDir(cwd, mavenCommand, mavenArgs...)
 		},
 	}
 
@@ -1263,7 +1562,7 @@ func tailLogFile(logFile string, lines int, follow bool) error {
 	}
 
 	// Follow the log file (like tail -f)
-	fmt.Printf("\n\x1b[33mFollowing log file (Ctrl+C to stop)...\x1b[0m\n\n")
+	fmt.Fprintf(utils.Stdout, "\n\x1b[33mFollowing log file (Ctrl+C to stop)...\x1b[0m\n\n")
 
 	file, err := os.Open(logFile)
 	if err != nil {
@@ -1,9 +1,19 @@
 package commands
 
 import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/docker"
+	"judo-cli-module/internal/utils"
 )
 
 func TestCreateGenerateCommand(t *testing.T) {
@@ -24,3 +34,88 @@ func TestCreateGenerateCommand(t *testing.T) {
 	// For now, we just ensure it's not nil
 	assert.NotNil(t, cmd.RunE)
 }
+
+func TestErrorHint(t *testing.T) {
+	assert.Contains(t, ErrorHint(fmt.Errorf("start: %w", docker.ErrDaemonUnreachable)), "judo doctor")
+	assert.Contains(t, ErrorHint(&utils.PortInUseError{Service: "Karaf", Port: 8181}), "--auto-ports")
+	assert.Contains(t, ErrorHint(fmt.Errorf("%w: pulling postgres", docker.ErrImagePull)), "judo images load")
+	assert.Empty(t, ErrorHint(errors.New("boom")))
+}
+
+func TestSchemaUpgradePasswordStaysOffCommandLine(t *testing.T) {
+	settings, err := writeSchemaSettings(`s3cr<&>t`, "")
+	assert.NoError(t, err)
+	defer os.Remove(settings)
+
+	info, err := os.Stat(settings)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	var doc struct {
+		Profiles []struct {
+			ID         string `xml:"id"`
+			DBPassword string `xml:"properties>dbPassword"`
+		} `xml:"profiles>profile"`
+		Active []string `xml:"activeProfiles>activeProfile"`
+	}
+	data, err := os.ReadFile(settings)
+	assert.NoError(t, err)
+	assert.NoError(t, xml.Unmarshal(data, &doc))
+	assert.Equal(t, `s3cr<&>t`, doc.Profiles[0].DBPassword)
+	assert.Equal(t, []string{doc.Profiles[0].ID}, doc.Active)
+
+	cfg := &config.Config{ModelDir: "/p", SchemaName: "app", PostgresPort: 5432}
+	args := schemaUpgradeArgs(cfg, config.Credentials{DBUser: "app", DBPassword: `s3cr<&>t`}, settings)
+	assert.Contains(t, args, "-DdbUser=app")
+	assert.Contains(t, strings.Join(args, " "), "-gs "+settings)
+	for _, arg := range args {
+		assert.NotContains(t, arg, "s3cr")
+		assert.NotContains(t, arg, "dbPassword")
+	}
+}
+
+func TestSchemaSettingsKeepGlobalSettings(t *testing.T) {
+	global := filepath.Join(t.TempDir(), "settings.xml")
+	assert.NoError(t, os.WriteFile(global, []byte(`<settings>
+  <!-- <profiles><profile><id>example</id></profile></profiles> -->
+  <mirrors>
+    <mirror><id>corp</id><url>https://nexus.example.com/maven</url><mirrorOf>*</mirrorOf></mirror>
+  </mirrors>
+  <profiles>
+    <profile><id>corp</id></profile>
+  </profiles>
+  <!--
+  <activeProfiles>
+    <activeProfile>example</activeProfile>
+  </activeProfiles>
+  -->
+</settings>
+`), 0o644))
+
+	settings, err := writeSchemaSettings("secret", global)
+	assert.NoError(t, err)
+	defer os.Remove(settings)
+
+	var doc struct {
+		Mirrors  []string `xml:"mirrors>mirror>id"`
+		Profiles []string `xml:"profiles>profile>id"`
+		Active   []string `xml:"activeProfiles>activeProfile"`
+	}
+	data, err := os.ReadFile(settings)
+	assert.NoError(t, err)
+	assert.NoError(t, xml.Unmarshal(data, &doc))
+	assert.Equal(t, []string{"corp"}, doc.Mirrors)
+	assert.Equal(t, []string{"corp", "judo-schema-upgrade"}, doc.Profiles)
+	assert.Equal(t, []string{"judo-schema-upgrade"}, doc.Active)
+}
+
+func TestImageLinePadsStateBeforeColoring(t *testing.T) {
+	img := docker.ProjectImage{Ref: "postgres:16", Sources: []string{"postgres"}}
+	line := imageLine(img, "missing", "33")
+	assert.Contains(t, line, "\x1b[33mmissing           \x1b[0m postgres")
+
+	utils.SetColor(false)
+	defer utils.SetColor(true)
+	plain := utils.Colored(line)
+	assert.Equal(t, 50+1+18+1, strings.LastIndex(plain, "postgres"))
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/docker"
+	"judo-cli-module/internal/help"
+	"judo-cli-module/internal/utils"
+)
+
+func CreateComposeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compose",
+		Short: "Inspect the docker compose environments of the project",
+		Long:  help.ComposeLongHelp(),
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "envs",
+		Short: "List the compose environments found in the project",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg := config.GetConfig()
+			envs := docker.ComposeEnvs(cfg)
+			if len(envs) == 0 {
+				fmt.Println("No compose environments found.")
+				return nil
+			}
+			for _, env := range envs {
+				marker := " "
+				if env.Name == cfg.ComposeEnv {
+					marker = "*"
+				}
+				files := make([]string, len(env.Files))
+				for i, f := range env.Files {
+					files[i] = filepath.Base(f)
+				}
+				fmt.Fprintf(utils.Stdout, "%s \x1b[32m%-28s\x1b[0m %s  %v\n", marker, env.Name, relativeToProject(cfg, env.Dir), files)
+			}
+			if _, err := docker.ResolveComposeEnv(cfg); err != nil && cfg.Runtime == "compose" {
+				fmt.Fprintf(utils.Stdout, "\n\x1b[33m⚠️  %v\x1b[0m\n", err)
+			}
+			return nil
+		},
+	})
+	return cmd
+}
+
+// relativeToProject shortens dir to a path relative to the project root when
+// it lies inside it.
+func relativeToProject(cfg *config.Config, dir string) string {
+	rel, err := filepath.Rel(cfg.Root(), dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return dir
+	}
+	return rel
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/help"
+	"judo-cli-module/internal/utils"
+)
+
+func CreateConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and edit JUDO project configuration",
+		Long:  help.ConfigLongHelp(),
+	}
+	cmd.AddCommand(
+		createConfigShowCommand(),
+		createConfigGetCommand(),
+		createConfigSetCommand(),
+		createConfigUnsetCommand(),
+		createConfigValidateCommand(),
+		createConfigMigrateCommand(),
+	)
+	return cmd
+}
+
+func createConfigShowCommand() *cobra.Command {
+	var origin, showSecrets bool
+	var options string
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective configuration",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if strings.TrimSpace(options) != "" {
+				if err := config.ApplyInlineOptions(options); err != nil {
+					return err
+				}
+			}
+			cfg := config.GetConfig()
+
+			width := 0
+			for _, k := range config.Keys() {
+				if len(k) > width {
+					width = len(k)
+				}
+			}
+			for _, k := range config.Keys() {
+				v, _ := cfg.Value(k)
+				if !showSecrets {
+					v = config.Mask(k, v)
+				}
+				if origin {
+					fmt.Fprintf(utils.Stdout, "%-*s = %-20s \x1b[90m# %s\x1b[0m\n", width, k, v, cfg.Origin(k))
+				} else {
+					fmt.Fprintf(utils.Stdout, "%-*s = %s\n", width, k, v)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&origin, "origin", false, "Show the file/line or source each value came from")
+	cmd.Flags().StringVar(&options, "options", "", "Preview inline options: key=value,key2=value2")
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Print passwords instead of masking them")
+	return cmd
+}
+
+func createConfigGetCommand() *cobra.Command {
+	var showSecrets bool
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of a configuration key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			key, ok := config.CanonicalKey(args[0])
+			if !ok {
+				return fmt.Errorf("unknown configuration key %q", args[0])
+			}
+			v, _ := config.GetConfig().Value(key)
+			if !showSecrets {
+				v = config.Mask(key, v)
+			}
+			fmt.Fprintln(utils.Stdout, v)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Print passwords instead of masking them")
+	return cmd
+}
+
+func createConfigSetCommand() *cobra.Command {
+	var global bool
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a key in judo.properties (or <profile>.properties with --env)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			value := strings.TrimSpace(args[1])
+			if err := config.ValidateRaw(args[0], value); err != nil {
+				return err
+			}
+			key, _ := config.CanonicalKey(args[0])
+			doc, err := config.LoadDocument(configTargetPath(global))
+			if err != nil {
+				return err
+			}
+			doc.SetProperty(key, value)
+			if err := doc.Save(); err != nil {
+				return fmt.Errorf("failed to write %s: %w", doc.Path, err)
+			}
+			fmt.Fprintf(utils.Stdout, "%s = %s (%s)\n", key, config.Mask(key, value), doc.Path)
+			if config.IsSecret(key) && !global {
+				fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  %s is usually committed; consider --global or %s for passwords\x1b[0m\n", doc.Path, config.EnvName(key))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&global, "global", false, "Write to the per-user ~/.judo/config instead")
+	return cmd
+}
+
+func createConfigUnsetCommand() *cobra.Command {
+	var global bool
+	cmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a key from judo.properties (or <profile>.properties with --env)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			doc, err := config.LoadDocument(configTargetPath(global))
+			if err != nil {
+				return err
+			}
+			if !doc.UnsetProperty(args[0]) {
+				fmt.Fprintf(utils.Stdout, "%s is not set in %s\n", args[0], doc.Path)
+				return nil
+			}
+			if err := doc.Save(); err != nil {
+				return fmt.Errorf("failed to write %s: %w", doc.Path, err)
+			}
+			fmt.Fprintf(utils.Stdout, "Removed %s from %s\n", args[0], doc.Path)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&global, "global", false, "Remove from the per-user ~/.judo/config instead")
+	return cmd
+}
+
+// configTargetPath returns the properties file edited by config set/unset
+func configTargetPath(global bool) string {
+	if global {
+		return config.GlobalConfigPath()
+	}
+	return config.GetConfig().PropertiesPath()
+}
+
+func createConfigValidateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [file...]",
+		Short: "Validate configuration files against the supported keys",
+		RunE: func(_ *cobra.Command, args []string) error {
+			var problems []config.Problem
+			if len(args) == 0 {
+				problems = config.GetConfig().Problems()
+			}
+			for _, f := range args {
+				p, err := config.ValidateFile(f)
+				if err != nil {
+					return err
+				}
+				problems = append(problems, p...)
+			}
+
+			if len(problems) == 0 {
+				fmt.Fprintln(utils.Stdout, "\x1b[32m✅ Configuration is valid\x1b[0m")
+				return nil
+			}
+			for _, p := range problems {
+				fmt.Fprintf(utils.Stdout, "\x1b[31m❌ %s\x1b[0m\n", p)
+			}
+			return fmt.Errorf("configuration is invalid: %d problem(s) found", len(problems))
+		},
+	}
+}
+
+func createConfigMigrateCommand() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "migrate [file]",
+		Short: "Rewrite dotted/legacy keys into the canonical form",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			path := config.GetConfig().PropertiesPath()
+			if len(args) == 1 {
+				path = args[0]
+			}
+			doc, err := config.LoadDocument(path)
+			if err != nil {
+				return err
+			}
+
+			changes := config.Migrate(doc)
+			unmapped := 0
+			for _, c := range changes {
+				switch c.Kind {
+				case config.MigrationUnmapped:
+					unmapped++
+					fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  %s\x1b[0m\n", c)
+				default:
+					fmt.Fprintf(utils.Stdout, "\x1b[32m✓ %s\x1b[0m\n", c)
+				}
+			}
+			if len(changes) == unmapped {
+				fmt.Fprintf(utils.Stdout, "%s is already in canonical form\n", path)
+				return nil
+			}
+			if dryRun {
+				fmt.Fprintln(utils.Stdout, "Dry run, nothing written.")
+				return nil
+			}
+			if err := doc.Save(); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			fmt.Fprintf(utils.Stdout, "Migrated %s\n", path)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report what would be changed")
+	return cmd
+}
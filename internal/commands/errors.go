@@ -0,0 +1,32 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package commands
+
+import (
+	"errors"
+
+	"judo-cli-module/internal/docker"
+	"judo-cli-module/internal/utils"
+)
+
+// ErrorHint suggests how to recover from the typed errors returned by the
+// docker, karaf and utils packages; it is empty for other errors.
+func ErrorHint(err error) string {
+	switch {
+	case errors.Is(err, docker.ErrDaemonUnreachable):
+		return "Start Docker or Podman, or select a docker context, and try again; 'judo doctor' shows what was tried."
+	case errors.Is(err, utils.ErrPortInUse):
+		return "Free the port, change it with 'judo config set <service>_port <port>', or run 'judo start --auto-ports'."
+	case errors.Is(err, docker.ErrImagePull):
+		return "Check the network and registry access, or import the images with 'judo images load <bundle.tar>' and use --offline."
+	}
+	return ""
+}
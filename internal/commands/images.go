@@ -0,0 +1,142 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/docker"
+	"judo-cli-module/internal/help"
+	"judo-cli-module/internal/utils"
+)
+
+func CreateImagesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "images",
+		Short: "List, export and import the container images of the project",
+		Long:  help.ImagesLongHelp(),
+	}
+	cmd.AddCommand(createImagesListCommand(), createImagesSaveCommand(), createImagesLoadCommand())
+	return cmd
+}
+
+func createImagesListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the images the project needs and whether they are available locally",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			images, err := docker.ProjectImages(ctx, config.GetConfig())
+			if err != nil {
+				fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  %v\x1b[0m\n", err)
+				if len(images) == 0 {
+					return err
+				}
+			}
+			_, reachable := docker.ActiveEngine()
+			if !reachable {
+				fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  Could not reach a Docker or Podman daemon, image states are unknown\x1b[0m\n")
+			}
+			for _, img := range images {
+				state, color := "unknown", "33"
+				if reachable {
+					state = "missing"
+					if present, size := docker.ImagePresent(ctx, img.Ref); present {
+						state, color = fmt.Sprintf("present %6.1fMB", float64(size)/1e6), "32"
+					}
+				}
+				fmt.Fprintln(utils.Stdout, imageLine(img, state, color))
+			}
+			return nil
+		},
+	}
+}
+
+func createImagesSaveCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "save",
+		Short: "Export the images of the project into a tar bundle",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			images, err := projectImages(ctx)
+			if err != nil {
+				return fmt.Errorf("%w\nfix the compose environment before saving, so no image is left out", err)
+			}
+			refs := make([]string, 0, len(images))
+			var missing []string
+			for _, img := range images {
+				if err := docker.EnsureImage(ctx, img.Ref); err != nil {
+					missing = append(missing, err.Error())
+					continue
+				}
+				refs = append(refs, img.Ref)
+			}
+			if len(missing) > 0 {
+				return fmt.Errorf("cannot export, some images are not available:\n  %s", strings.Join(missing, "\n  "))
+			}
+
+			fmt.Printf("Exporting %d image(s) to %s...\n", len(refs), output)
+			if err := docker.SaveImages(ctx, refs, output); err != nil {
+				return err
+			}
+			if fi, err := os.Stat(output); err == nil {
+				fmt.Fprintf(utils.Stdout, "\x1b[32m✅ Saved %s (%.1fMB)\x1b[0m\n", output, float64(fi.Size())/1e6)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "judo-images.tar", "Bundle file to write")
+	return cmd
+}
+
+func createImagesLoadCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "load <bundle.tar>",
+		Short: "Import images from a bundle written by 'judo images save'",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Printf("Loading images from %s...\n", args[0])
+			if err := docker.LoadImages(cmd.Context(), args[0], os.Stdout); err != nil {
+				return err
+			}
+			fmt.Fprintf(utils.Stdout, "\x1b[32m✅ Images loaded\x1b[0m\n")
+			return nil
+		},
+	}
+}
+
+// imageLine formats a row of 'images list'. The state is padded before it is
+// colored, as the escape codes would otherwise count towards the width.
+func imageLine(img docker.ProjectImage, state, color string) string {
+	return fmt.Sprintf("%-50s \x1b[%sm%-18s\x1b[0m %s", img.Ref, color, state, strings.Join(img.Sources, ", "))
+}
+
+// projectImages lists the project images, printing compose problems as
+// warnings so the remaining images can still be used.
+func projectImages(ctx context.Context) ([]docker.ProjectImage, error) {
+	if _, ok := docker.ActiveEngine(); !ok {
+		return nil, docker.ErrDaemonUnreachable
+	}
+	images, err := docker.ProjectImages(ctx, config.GetConfig())
+	if err != nil {
+		fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  %v\x1b[0m\n", err)
+	}
+	return images, err
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"judo-cli-module/internal/docker"
+	"judo-cli-module/internal/help"
+	"judo-cli-module/internal/utils"
+)
+
+func CreatePsCommand() *cobra.Command {
+	var pruneOrphans, yes bool
+	cmd := &cobra.Command{
+		Use:   "ps",
+		Short: "List JUDO-managed containers, volumes and networks of all projects",
+		Long:  help.PsLongHelp(),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			resources, err := docker.ListManaged(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list Docker resources: %w", err)
+			}
+			if len(resources) == 0 {
+				fmt.Println("No JUDO-managed Docker resources found.")
+				return nil
+			}
+
+			var orphans []docker.Resource
+			for i, group := range groupResources(resources) {
+				if i > 0 {
+					fmt.Println()
+				}
+				printProjectHeader(group[0])
+				for _, r := range group {
+					detail := r.Service
+					if r.Kind == docker.KindContainer {
+						detail = fmt.Sprintf("%-14s %-9s %s", r.Service, r.State, r.Image)
+					}
+					fmt.Printf("  %-10s %-36s %s\n", r.Kind, r.Name, detail)
+					if r.Orphan() {
+						orphans = append(orphans, r)
+					}
+				}
+			}
+
+			if !pruneOrphans {
+				if len(orphans) > 0 {
+					fmt.Fprintf(utils.Stdout, "\n\x1b[33m%d orphaned resource(s); remove them with 'judo ps --prune-orphans'\x1b[0m\n", len(orphans))
+				}
+				return nil
+			}
+			if !yes {
+				if orphans, err = confirmOrphanData(orphans); err != nil {
+					return err
+				}
+			}
+			return pruneResources(ctx, orphans)
+		},
+	}
+	cmd.Flags().BoolVar(&pruneOrphans, "prune-orphans", false, "Remove resources whose project directory no longer exists")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask before removing orphaned data volumes")
+	return cmd
+}
+
+// groupResources splits the resources, sorted by project and profile, into
+// one group per project and profile, so every group shares the header
+// printProjectHeader prints for its first resource.
+func groupResources(resources []docker.Resource) [][]docker.Resource {
+	var groups [][]docker.Resource
+	for i, r := range resources {
+		if i == 0 || r.Project != resources[i-1].Project || r.Profile != resources[i-1].Profile {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], r)
+	}
+	return groups
+}
+
+func printProjectHeader(r docker.Resource) {
+	name := r.Project
+	if name == "" {
+		name = "(unknown project)"
+	}
+	if r.Orphan() {
+		fmt.Fprintf(utils.Stdout, "\x1b[33m%s\x1b[0m  app=%s profile=%s \x1b[33m(orphan: project directory no longer exists)\x1b[0m\n", name, r.App, r.Profile)
+		return
+	}
+	fmt.Fprintf(utils.Stdout, "\x1b[1m%s\x1b[0m  app=%s profile=%s\n", name, r.App, r.Profile)
+}
+
+// confirmOrphanData asks before orphaned volumes are pruned: a project that
+// was moved, renamed or is on an unmounted disk looks orphaned as well. When
+// the answer is no, the volumes are left out and the rest is still pruned.
+func confirmOrphanData(orphans []docker.Resource) ([]docker.Resource, error) {
+	others, volumes := splitDataResources(orphans)
+	var data []string
+	for _, r := range volumes {
+		data = append(data, fmt.Sprintf("volume %s (%s)", r.Name, r.Project))
+	}
+	if len(data) == 0 {
+		return orphans, nil
+	}
+	fmt.Println()
+	ok, err := confirmDataLoss(data)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return orphans, nil
+	}
+	fmt.Printf("Keeping %d data volume(s).\n", len(volumes))
+	return others, nil
+}
+
+// splitDataResources separates the volumes, which hold the data of the
+// services, from the other resources.
+func splitDataResources(resources []docker.Resource) (others, volumes []docker.Resource) {
+	for _, r := range resources {
+		if r.Kind == docker.KindVolume {
+			volumes = append(volumes, r)
+		} else {
+			others = append(others, r)
+		}
+	}
+	return others, volumes
+}
+
+// pruneResources removes the given resources in list order (containers
+// before volumes and networks) and reports every failure.
+func pruneResources(ctx context.Context, resources []docker.Resource) error {
+	if len(resources) == 0 {
+		fmt.Println("\nNo orphaned resources to remove.")
+		return nil
+	}
+	fmt.Println()
+	failed := 0
+	for _, kind := range []string{docker.KindContainer, docker.KindVolume, docker.KindNetwork} {
+		for _, r := range resources {
+			if r.Kind != kind {
+				continue
+			}
+			if err := docker.RemoveResource(ctx, r); err != nil {
+				failed++
+				fmt.Fprintf(utils.Stdout, "\x1b[31m❌ Failed to remove %s %s: %v\x1b[0m\n", r.Kind, r.Name, err)
+				continue
+			}
+			fmt.Fprintf(utils.Stdout, "\x1b[32m✅ Removed %s %s\x1b[0m\n", r.Kind, r.Name)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d orphaned resources could not be removed", failed, len(resources))
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"judo-cli-module/internal/docker"
+)
+
+func TestSplitDataResources(t *testing.T) {
+	resources := []docker.Resource{
+		{Kind: docker.KindContainer, Name: "postgres-app"},
+		{Kind: docker.KindVolume, Name: "app_postgresql_db"},
+		{Kind: docker.KindNetwork, Name: "app"},
+	}
+	others, volumes := splitDataResources(resources)
+	assert.Equal(t, []docker.Resource{resources[0], resources[2]}, others)
+	assert.Equal(t, []docker.Resource{resources[1]}, volumes)
+}
+
+func TestGroupResourcesByProjectAndProfile(t *testing.T) {
+	resources := []docker.Resource{
+		{Kind: docker.KindContainer, Name: "postgres-app", Project: "/p/app", Profile: "dev"},
+		{Kind: docker.KindVolume, Name: "app_postgresql_db", Project: "/p/app", Profile: "dev"},
+		{Kind: docker.KindContainer, Name: "postgres-app_test", Project: "/p/app", Profile: "test"},
+		{Kind: docker.KindContainer, Name: "postgres-shop", Project: "/p/shop", Profile: "test"},
+	}
+	assert.Equal(t, [][]docker.Resource{resources[:2], resources[2:3], resources[3:]}, groupResources(resources))
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/docker"
+	"judo-cli-module/internal/help"
+	"judo-cli-module/internal/utils"
+)
+
+func CreateWatchServicesCommand() *cobra.Command {
+	opts := docker.WatchOptions{}
+	cmd := &cobra.Command{
+		Use:   "watch-services",
+		Short: "Report crashed, OOM-killed or unhealthy service containers",
+		Long:  help.WatchServicesLongHelp(),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := requireJudoProject(); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			if !docker.IsDockerRunning(ctx) {
+				return docker.ErrDaemonUnreachable
+			}
+			cfg := config.GetConfig()
+			fmt.Printf("Watching the containers of %s (Ctrl-C to stop)...\n", cfg.Root())
+			return docker.WatchServices(ctx, cfg, opts, utils.Stdout)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.Restart, "restart", false, "Restart containers that died or became unhealthy")
+	cmd.Flags().IntVar(&opts.MaxRestarts, "max-restarts", 3, "Restarts per container before giving up")
+	cmd.Flags().IntVarP(&opts.LogLines, "lines", "n", 20, "Log lines shown with each event (0 for none)")
+	return cmd
+}
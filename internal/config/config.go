@@ -12,10 +12,11 @@ package config
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
-	"strconv"
+	"sort"
 	"strings"
 
 	"judo-cli-module/internal/utils"
@@ -23,25 +24,48 @@ import (
 
 var (
 	instance *Config
+	reported = map[string]bool{}
 )
 
 func GetConfig() *Config {
 	if instance == nil {
-		cwd, _ := os.Getwd()
+		root := ProjectRoot()
 		instance = &Config{
-			AppName:      filepath.Base(cwd),
-			ModelDir:     cwd,
-			AppDir:       filepath.Join(cwd, "application"),
-			KarafDir:     filepath.Join(cwd, "application", ".karaf"),
+			AppName:      filepath.Base(root),
+			ModelDir:     root,
+			AppDir:       filepath.Join(root, "application"),
+			KarafDir:     filepath.Join(root, "application", ".karaf"),
 			Runtime:      "karaf",
 			DBType:       "hsqldb",
 			KarafPort:    8181,
 			PostgresPort: 5432,
 			KeycloakPort: 8080,
+			Color:        "auto",
+			JavaHeap:     "1024m",
+			Profile:      Profile,
+			projectDir:   root,
+
+			PostgresImage:   "postgres",
+			PostgresVersion: "16.2",
+			KeycloakImage:   "quay.io/keycloak/keycloak",
+			KeycloakVersion: "23.0",
+
+			PostgresTimeout: 60,
+			KeycloakTimeout: 120,
+			KarafTimeout:    180,
 		}
 		instance.SchemaName = instance.AppName
 		instance.KeycloakName = instance.AppName
+		defaults := *instance
+		instance.defaults = &defaults
 		instance.loadProperties()
+		for _, p := range instance.problems {
+			// a reloaded configuration reports each problem only once
+			if !p.Unknown && !reported[p.String()] {
+				reported[p.String()] = true
+				fmt.Fprintf(utils.Stderr, "\x1b[33m⚠️  %s (ignored)\x1b[0m\n", p)
+			}
+		}
 	}
 	return instance
 }
@@ -79,6 +103,9 @@ type JudoOptions struct {
 	VersionNumber     string
 	ExtraMavenArgs    string
 	DumpName          string
+	PullPolicy        string // "missing" | "always", see docker.EnsureImage
+	Offline           bool   // never pull images
+	Recreate          bool   // recreate containers whose settings drifted
 }
 
 // State used by prune command flags
@@ -104,107 +131,302 @@ type Config struct {
 	PostgresPort         int
 	KeycloakPort         int
 	Profile              string
+
+	// Personal defaults, usually kept in ~/.judo/config
+	DefaultProfile string
+	Color          string // "auto" | "always" | "never"
+	MavenArgs      string
+	JavaHeap       string
+	PortOffset     int
+
+	// Container images; see PostgresImageRef and KeycloakImageRef
+	PostgresImage   string
+	PostgresVersion string
+	KeycloakImage   string
+	KeycloakVersion string
+
+	// Readiness timeouts in seconds
+	PostgresTimeout int
+	KeycloakTimeout int
+	KarafTimeout    int
+
+	// Container tuning; see PostgresSettings and KeycloakSettings
+	PostgresMemory string
+	PostgresCPUs   string
+	PostgresEnv    string
+	PostgresArgs   string
+	PostgresMounts string
+	KeycloakMemory string
+	KeycloakCPUs   string
+	KeycloakEnv    string
+	KeycloakArgs   string
+	KeycloakMounts string
+
+	// Credentials; see Credentials() for the generated defaults
+	DBUser                string
+	DBPassword            string
+	KeycloakAdminPassword string
+
+	projectDir string
+	defaults   *Config // the values before any layer applied, see set
+	origins    map[string]Origin
+	problems   []Problem
 }
 
 var Options JudoOptions
 
-func (c *Config) loadProperties() {
-	// prefer <profile>.properties, then judo.properties
-	var props map[string]string
-	candidates := []string{
-		filepath.Join(c.ModelDir, c.Profile+".properties"),
-		filepath.Join(c.ModelDir, "judo.properties"),
+// Configuration layers, from lowest to highest precedence.
+const (
+	LayerDefault  = "default"
+	LayerGlobal   = "global"
+	LayerVersions = "versions"
+	LayerProject  = "project"
+	LayerProfile  = "profile"
+	LayerState    = "state"
+	LayerEnv      = "env"
+	LayerOptions  = "options"
+)
+
+// Origin records where the effective value of a configuration key came from.
+type Origin struct {
+	Layer    string
+	Location string // file path or environment variable name
+	Line     int
+}
+
+func (o Origin) String() string {
+	if o.Location == "" {
+		return o.Layer
 	}
-	for _, p := range candidates {
-		if _, err := os.Stat(p); err == nil {
-			props = readProperties(p)
-			break
-		}
+	return fmt.Sprintf("%s (%s)", o.where(), o.Layer)
+}
+
+func (o Origin) where() string {
+	switch {
+	case o.Location != "" && o.Line > 0:
+		return fmt.Sprintf("%s:%d", o.Location, o.Line)
+	case o.Location != "":
+		return o.Location
 	}
-	if props == nil {
-		return
+	return o.Layer
+}
+
+// Problem is an unknown key or invalid value found in a configuration source.
+type Problem struct {
+	Origin  Origin
+	Key     string
+	Unknown bool
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Origin.where(), p.Message)
+}
+
+// Value returns the effective value of the given key.
+func (c *Config) Value(key string) (string, bool) {
+	p, ok := lookupProperty(key)
+	if !ok {
+		return "", false
+	}
+	return p.get(c), true
+}
+
+// Origin returns where the effective value of the given key was set.
+func (c *Config) Origin(key string) Origin {
+	if o, ok := c.origins[key]; ok {
+		return o
 	}
+	return Origin{Layer: LayerDefault}
+}
+
+// Problems returns every unknown key and invalid value met while loading.
+func (c *Config) Problems() []Problem {
+	return c.problems
+}
 
-	if v := props["model_dir"]; v != "" {
-		if filepath.IsAbs(v) {
-			c.ModelDir = v
-		} else {
-			c.ModelDir = filepath.Clean(filepath.Join(c.ModelDir, v))
+// set validates and applies a single key/value pair and records its origin.
+// An empty value resets the key to its default, so a higher layer can clear
+// what a lower one set, e.g. -o compose_access_ip= or JUDO_DB_PASSWORD=.
+func (c *Config) set(key, value string, origin Origin) error {
+	p, ok := lookupProperty(key)
+	if !ok {
+		return Validate(key, value)
+	}
+	if strings.TrimSpace(value) == "" {
+		defaults := c.defaults
+		if defaults == nil {
+			defaults = &Config{}
+		}
+		p.set(c, p.get(defaults))
+	} else {
+		if err := Validate(key, value); err != nil {
+			return err
 		}
+		v, _ := p.check(value)
+		p.set(c, v)
 	}
-	if v := props["app_name"]; v != "" {
-		c.AppName = v
+	if c.origins == nil {
+		c.origins = map[string]Origin{}
 	}
-	if v := props["schema_name"]; v != "" {
-		c.SchemaName = v
+	c.origins[p.Name] = origin
+	return nil
+}
+
+func (c *Config) record(key string, origin Origin, err error) {
+	_, known := lookupProperty(key)
+	c.problems = append(c.problems, Problem{Origin: origin, Key: key, Unknown: !known, Message: err.Error()})
+}
+
+// loadProperties applies the configuration layers on top of the built-in
+// defaults: ~/.judo/config, the versions pinned in judo-version.properties,
+// judo.properties, <profile>.properties, the ports chosen by --auto-ports
+// and finally JUDO_* environment variables.
+func (c *Config) loadProperties() {
+	projectDir := c.projectDir
+
+	if p := GlobalConfigPath(); p != "" {
+		c.loadFile(p, LayerGlobal)
 	}
-	if v := props["keycloak_name"]; v != "" {
-		c.KeycloakName = v
+	c.loadVersions(filepath.Join(projectDir, "judo-version.properties"))
+	c.loadFile(filepath.Join(projectDir, "judo.properties"), LayerProject)
+	if c.Profile != "" && c.Profile != "judo" {
+		c.loadFile(filepath.Join(projectDir, c.Profile+".properties"), LayerProfile)
 	}
-	if v := props["runtime"]; v != "" {
-		c.Runtime = v
+	c.loadFile(c.StatePath(), LayerState)
+	c.loadEnv()
+	c.applyPortOffset()
+}
+
+// applyPortOffset shifts the service ports by port_offset, so developers
+// running several JUDO apps side by side can keep the project ports intact.
+// Ports given later with --options and ports chosen by --auto-ports, which
+// already include the offset, are used as is.
+func (c *Config) applyPortOffset() {
+	if c.PortOffset == 0 {
+		return
 	}
-	if v := props["dbtype"]; v != "" {
-		if v == "postgres" {
-			c.DBType = "postgresql"
-		} else {
-			c.DBType = v
+	for key, port := range map[string]*int{"karaf_port": &c.KarafPort, "postgres_port": &c.PostgresPort, "keycloak_port": &c.KeycloakPort} {
+		if c.Origin(key).Layer != LayerState {
+			*port += c.PortOffset
 		}
 	}
-	if v := props["compose_env"]; v != "" {
-		c.ComposeEnv = v
+}
+
+// UseColor reports whether colored output is wanted: color=always/never
+// decides explicitly, otherwise colors are used on terminals unless NO_COLOR
+// is set.
+func (c *Config) UseColor() bool {
+	switch c.Color {
+	case "always":
+		return true
+	case "never":
+		return false
 	}
-	if v := props["compose_access_ip"]; v != "" {
-		c.ComposeAccessIP = v
+	if os.Getenv("NO_COLOR") != "" {
+		return false
 	}
-	if v := props["karaf_port"]; v != "" {
-		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
-			c.KarafPort = n
+	return utils.IsTerminal(os.Stdout)
+}
+
+func (c *Config) loadFile(path string, layer string) {
+	entries, problems := resolveProperties(path, layer, c.Value)
+	c.problems = append(c.problems, problems...)
+	for _, e := range entries {
+		origin := Origin{Layer: layer, Location: e.File, Line: e.Line}
+		if err := c.set(e.Key, e.Value, origin); err != nil {
+			c.record(e.Key, origin, err)
 		}
 	}
-	if v := props["postgres_port"]; v != "" {
-		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
-			c.PostgresPort = n
+}
+
+// loadVersions picks the image versions from judo-version.properties. The
+// other entries of that file are meant for Maven and are left alone.
+func (c *Config) loadVersions(path string) {
+	d, err := LoadDocument(path)
+	if err != nil {
+		return
+	}
+	for _, e := range d.Entries() {
+		if p, ok := lookupProperty(e.Key); !ok || (p.Name != "postgres_version" && p.Name != "keycloak_version") {
+			continue
+		}
+		origin := Origin{Layer: LayerVersions, Location: path, Line: e.Line}
+		if err := c.set(e.Key, e.Value, origin); err != nil {
+			c.record(e.Key, origin, err)
 		}
 	}
-	if v := props["keycloak_port"]; v != "" {
-		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
-			c.KeycloakPort = n
+}
+
+// loadEnv applies JUDO_<KEY> environment variables. Variables with the JUDO_
+// prefix that do not match a key are recorded as problems, except those the
+// CLI itself hands over to the runtime (JUDO_PLATFORM_*) or reads elsewhere.
+func (c *Config) loadEnv() {
+	env := os.Environ()
+	sort.Strings(env)
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(name, envPrefix) || isReservedEnv(name) {
+			continue
+		}
+		origin := Origin{Layer: LayerEnv, Location: name}
+		key := strings.ToLower(strings.TrimPrefix(name, envPrefix))
+		if p, ok := lookupProperty(key); !ok || p.Name != key {
+			msg := fmt.Sprintf("unknown configuration variable %s", name)
+			if s := Suggest(key); s != "" {
+				msg += fmt.Sprintf(", did you mean %s?", EnvName(s))
+			}
+			c.problems = append(c.problems, Problem{Origin: origin, Key: key, Unknown: true, Message: msg})
+			continue
+		}
+		if err := c.set(key, value, origin); err != nil {
+			c.record(key, origin, err)
 		}
 	}
-	if v := props["karaf_enable_admin_user"]; v != "" {
-		c.KarafEnableAdminUser = (v == "1" || strings.EqualFold(v, "true"))
+}
+
+// ValidateFile checks every entry of a properties file against the schema.
+func ValidateFile(path string) ([]Problem, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
 	}
-	if v := props["java_compiler"]; v != "" {
-		c.JavaCompiler = v
+	c := &Config{ModelDir: filepath.Dir(path), projectDir: filepath.Dir(path)}
+	c.loadFile(path, LayerProject)
+	return c.problems, nil
+}
+
+// PropertiesPath returns the project properties file of the active profile:
+// <profile>.properties when a profile other than the default is selected,
+// judo.properties otherwise.
+func (c *Config) PropertiesPath() string {
+	if c.Profile != "" && c.Profile != "judo" {
+		return filepath.Join(c.projectDir, c.Profile+".properties")
 	}
+	return filepath.Join(c.projectDir, "judo.properties")
 }
 
-func readProperties(path string) map[string]string {
-	f, err := os.Open(path)
+// GlobalConfigPath returns the location of the per-user configuration file.
+func GlobalConfigPath() string {
+	home, err := os.UserHomeDir()
 	if err != nil {
-		return nil
+		return ""
 	}
-	defer f.Close()
+	return filepath.Join(home, ".judo", "config")
+}
 
-	props := map[string]string{}
-	scanner := NewScanner(f)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
-			continue
-		}
-		if i := strings.IndexAny(line, "=:"); i >= 0 {
-			k := strings.TrimSpace(line[:i])
-			v := strings.TrimSpace(line[i+1:])
-			props[k] = v
-		}
-	}
-	return props
+// propertyEntry is a single key/value pair read from a properties file.
+type propertyEntry struct {
+	Key   string
+	Value string
+	File  string
+	Line  int
 }
 
-func ApplyInlineOptions(s string) {
+// ApplyInlineOptions applies the comma separated key=value pairs given with
+// --options on top of every other configuration layer.
+func ApplyInlineOptions(s string) error {
 	cfg := GetConfig()
+	var errs []string
 	for _, pair := range strings.Split(s, ",") {
 		pair = strings.TrimSpace(pair)
 		if pair == "" {
@@ -216,45 +438,14 @@ func ApplyInlineOptions(s string) {
 		if len(kv) == 2 {
 			val = strings.TrimSpace(kv[1])
 		}
-		switch key {
-		case "runtime":
-			cfg.Runtime = val // "karaf" | "compose"
-		case "dbtype":
-			if val == "postgres" {
-				cfg.DBType = "postgresql"
-			} else {
-				cfg.DBType = val
-			}
-		case "compose_env":
-			cfg.ComposeEnv = val
-		case "model_dir":
-			if val != "" {
-				if filepath.IsAbs(val) {
-					cfg.ModelDir = filepath.Clean(val)
-				} else {
-					cfg.ModelDir = filepath.Clean(filepath.Join(cfg.ModelDir, val))
-				}
-			}
-		case "karaf_port":
-			if n, err := strconv.Atoi(val); err == nil {
-				cfg.KarafPort = n
-			}
-		case "postgres_port":
-			if n, err := strconv.Atoi(val); err == nil {
-				cfg.PostgresPort = n
-			}
-		case "keycloak_port":
-			if n, err := strconv.Atoi(val); err == nil {
-				cfg.KeycloakPort = n
-			}
-		case "compose_access_ip":
-			cfg.ComposeAccessIP = val
-		case "karaf_enable_admin_user":
-			cfg.KarafEnableAdminUser = (val == "1" || strings.EqualFold(val, "true"))
-		case "java_compiler":
-			cfg.JavaCompiler = val
+		if err := cfg.set(key, val, Origin{Layer: LayerOptions, Location: "--options"}); err != nil {
+			errs = append(errs, err.Error())
 		}
 	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid --options: %s", strings.Join(errs, "; "))
+	}
+	return nil
 }
 
 // NewScanner is a placeholder for bufio.NewScanner.
@@ -273,6 +464,13 @@ func NewScanner(r io.Reader) Scanner {
 	return &bufioScanner{bufio.NewScanner(r)}
 }
 
+// Reload discards the loaded configuration, so that the current Profile,
+// ProjectDir and file contents are read again, and returns the new one.
+func Reload() *Config {
+	instance = nil
+	return GetConfig()
+}
+
 // LoadProperties loads the configuration properties for the current profile
 func LoadProperties() {
 	// This function is called in PersistentPreRun to ensure config is loaded
@@ -289,16 +487,90 @@ func SetupEnvironment() {
 // Profile is the global profile variable used by the CLI
 var Profile string
 
-// IsProjectInitialized checks if a JUDO project is initialized in the current directory
-func IsProjectInitialized() bool {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return false
+// DefaultProfile returns the profile used when --env is not given: the value
+// of JUDO_PROFILE, default_profile from ~/.judo/config, or "judo".
+func DefaultProfile() string {
+	if p := strings.TrimSpace(os.Getenv(ProfileEnv)); p != "" {
+		return p
+	}
+	if d, err := LoadDocument(GlobalConfigPath()); err == nil {
+		if p, ok := d.Get("default_profile"); ok && p != "" {
+			return p
+		}
+	}
+	return "judo"
+}
+
+// AvailableProfiles lists the profiles that can be selected in the project
+// at root: "judo" and every <profile>.properties next to judo.properties.
+func AvailableProfiles(root string) []string {
+	profiles := []string{"judo"}
+	matches, _ := filepath.Glob(filepath.Join(root, "*.properties"))
+	sort.Strings(matches)
+	for _, m := range matches {
+		name := strings.TrimSuffix(filepath.Base(m), ".properties")
+		if name == "judo" || name == "judo-version" {
+			continue
+		}
+		profiles = append(profiles, name)
+	}
+	return profiles
+}
+
+// ProjectDir is the directory given with the global --project-dir/-C flag.
+// When set, project discovery starts there instead of the working directory.
+var ProjectDir string
+
+// StartDir returns the directory project discovery starts from.
+func StartDir() string {
+	if ProjectDir != "" {
+		if abs, err := filepath.Abs(ProjectDir); err == nil {
+			return abs
+		}
+		return ProjectDir
 	}
+	cwd, _ := os.Getwd()
+	return cwd
+}
 
-	// Check for judo.properties or judo-version.properties
-	judoPropsPath := filepath.Join(cwd, "judo.properties")
-	judoVersionPropsPath := filepath.Join(cwd, "judo-version.properties")
+// FindProjectRoot walks up from dir to the nearest directory containing
+// judo.properties or judo-version.properties, like git does for .git.
+func FindProjectRoot(dir string) (string, bool) {
+	for {
+		if isProjectDir(dir) {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// ProjectRoot returns the discovered project root, or the start directory
+// when no project is found above it.
+func ProjectRoot() string {
+	start := StartDir()
+	if root, ok := FindProjectRoot(start); ok {
+		return root
+	}
+	return start
+}
+
+// Root returns the project root the configuration was loaded from.
+func (c *Config) Root() string {
+	return c.projectDir
+}
+
+// IsProjectInitialized checks if a JUDO project is initialized in the start
+// directory or any of its parents
+func IsProjectInitialized() bool {
+	_, ok := FindProjectRoot(StartDir())
+	return ok
+}
 
-	return utils.FileExists(judoPropsPath) || utils.FileExists(judoVersionPropsPath)
+func isProjectDir(dir string) bool {
+	return utils.FileExists(filepath.Join(dir, "judo.properties")) ||
+		utils.FileExists(filepath.Join(dir, "judo-version.properties"))
 }
@@ -0,0 +1,280 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestConfig(dir, profile string) *Config {
+	return &Config{
+		AppName:      "app",
+		SchemaName:   "app",
+		KeycloakName: "app",
+		ModelDir:     dir,
+		Runtime:      "karaf",
+		DBType:       "hsqldb",
+		KarafPort:    8181,
+		PostgresPort: 5432,
+		KeycloakPort: 8080,
+		Profile:      profile,
+		projectDir:   dir,
+	}
+}
+
+func TestLoadPropertiesLayering(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(home, ".judo"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(home, ".judo", "config"), []byte("keycloak_port=9080\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "judo.properties"), []byte("# project\ndbtype=postgres\nkaraf_port=8282\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dev.properties"), []byte("karaf_port: 8383\n"), 0o644))
+	t.Setenv("JUDO_POSTGRES_PORT", "5555")
+
+	cfg := newTestConfig(dir, "dev")
+	cfg.loadProperties()
+
+	// the profile file overrides a single key instead of replacing judo.properties
+	assert.Equal(t, "postgresql", cfg.DBType)
+	assert.Equal(t, 8383, cfg.KarafPort)
+	assert.Equal(t, 9080, cfg.KeycloakPort)
+	assert.Equal(t, 5555, cfg.PostgresPort)
+
+	assert.Equal(t, Origin{Layer: LayerProject, Location: filepath.Join(dir, "judo.properties"), Line: 2}, cfg.Origin("dbtype"))
+	assert.Equal(t, Origin{Layer: LayerProfile, Location: filepath.Join(dir, "dev.properties"), Line: 1}, cfg.Origin("karaf_port"))
+	assert.Equal(t, LayerGlobal, cfg.Origin("keycloak_port").Layer)
+	assert.Equal(t, Origin{Layer: LayerEnv, Location: "JUDO_POSTGRES_PORT"}, cfg.Origin("postgres_port"))
+	assert.Equal(t, LayerDefault, cfg.Origin("runtime").Layer)
+}
+
+func TestLoadPropertiesReportsProblems(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "judo.properties"), []byte("karafport=8282\nkaraf_port=abc\nruntime=docker\n"), 0o644))
+
+	cfg := newTestConfig(dir, "")
+	cfg.loadProperties()
+
+	assert.Equal(t, 8181, cfg.KarafPort)
+	assert.Equal(t, "karaf", cfg.Runtime)
+	assert.Equal(t, LayerDefault, cfg.Origin("karaf_port").Layer)
+
+	problems := cfg.Problems()
+	assert.Len(t, problems, 3)
+	assert.True(t, problems[0].Unknown)
+	assert.Contains(t, problems[0].String(), `judo.properties:1: unknown configuration key "karafport", did you mean "karaf_port"?`)
+	assert.Contains(t, problems[1].Message, "not a valid port number")
+	assert.Contains(t, problems[2].Message, "not one of karaf|compose")
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "judo.properties"), []byte("runtime=karaf\n"), 0o644))
+	t.Setenv("JUDO_RUNTIME", "compose")
+	t.Setenv("JUDO_SCHEMA_NAME", "ci_schema")
+	t.Setenv("JUDO_KARAFPORT", "8282")
+	t.Setenv("JUDO_PLATFORM_RDBMS_DIALECT", "postgresql")
+	t.Setenv("JUDO_PROFILE", "ci")
+
+	cfg := newTestConfig(dir, "")
+	cfg.loadProperties()
+
+	assert.Equal(t, "compose", cfg.Runtime)
+	assert.Equal(t, "ci_schema", cfg.SchemaName)
+	assert.Equal(t, Origin{Layer: LayerEnv, Location: "JUDO_RUNTIME"}, cfg.Origin("runtime"))
+	assert.Equal(t, "ci", DefaultProfile())
+
+	problems := cfg.Problems()
+	assert.Len(t, problems, 1)
+	assert.Equal(t, "JUDO_KARAFPORT: unknown configuration variable JUDO_KARAFPORT, did you mean JUDO_KARAF_PORT?", problems[0].String())
+}
+
+func TestModelDirIsRelativeToProjectRoot(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "judo.properties"), []byte("model_dir=model\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dev.properties"), []byte("model_dir=model\n"), 0o644))
+
+	cfg := newTestConfig(dir, "dev")
+	cfg.loadProperties()
+	assert.Equal(t, filepath.Join(dir, "model"), cfg.ModelDir)
+	assert.Equal(t, filepath.Join(dir, "model", "application"), cfg.AppDir)
+	assert.Equal(t, filepath.Join(dir, "model", "application", ".karaf"), cfg.KarafDir)
+}
+
+func TestGlobalUserDefaults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ProfileEnv, "")
+	dir := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(home, ".judo"), 0o755))
+	assert.NoError(t, os.WriteFile(GlobalConfigPath(), []byte("default_profile=dev\nport_offset=100\njava_heap=2g\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "judo.properties"), []byte("karaf_port=8282\n"), 0o644))
+
+	cfg := newTestConfig(dir, "")
+	cfg.loadProperties()
+
+	assert.Equal(t, "dev", DefaultProfile())
+	assert.Equal(t, "2g", cfg.JavaHeap)
+	assert.Equal(t, 8382, cfg.KarafPort)
+	assert.Equal(t, 5532, cfg.PostgresPort)
+	assert.Equal(t, 8180, cfg.KeycloakPort)
+
+	// config set --global may store passwords there
+	doc, err := LoadDocument(GlobalConfigPath())
+	assert.NoError(t, err)
+	doc.SetProperty("db_password", "s3cret")
+	assert.NoError(t, doc.Save())
+	info, err := os.Stat(GlobalConfigPath())
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestFindProjectRoot(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "application", "frontend-react")
+	assert.NoError(t, os.MkdirAll(sub, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "judo-version.properties"), nil, 0o644))
+
+	found, ok := FindProjectRoot(sub)
+	assert.True(t, ok)
+	assert.Equal(t, root, found)
+
+	_, ok = FindProjectRoot(t.TempDir())
+	assert.False(t, ok)
+}
+
+func TestCredentials(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+
+	cfg := newTestConfig(dir, "")
+	creds, err := cfg.Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "app", creds.DBUser)
+	assert.Len(t, creds.DBPassword, 32)
+	assert.NotEqual(t, creds.DBPassword, creds.KeycloakAdminPassword)
+
+	info, err := os.Stat(cfg.SecretsPath())
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// generated once, then reused
+	again, err := newTestConfig(dir, "").Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, creds, again)
+
+	// configured values win over the generated ones
+	t.Setenv("JUDO_DB_PASSWORD", "s3cret")
+	cfg = newTestConfig(dir, "")
+	cfg.loadProperties()
+	creds, err = cfg.Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", creds.DBPassword)
+	assert.Equal(t, "********", Mask("db_password", creds.DBPassword))
+	assert.Equal(t, "app", Mask("db_user", "app"))
+}
+
+func TestCredentialsExistingDatabase(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+
+	// the database volume exists, so its password is kept
+	cfg := newTestConfig(dir, "")
+	assert.NoError(t, cfg.KeepLegacyDBPassword())
+	creds, err := cfg.Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, cfg.SchemaName, creds.DBPassword)
+	assert.Len(t, creds.KeycloakAdminPassword, 32)
+
+	// a generated password is not replaced
+	other := t.TempDir()
+	cfg = newTestConfig(other, "")
+	generated, err := cfg.Credentials()
+	assert.NoError(t, err)
+	assert.NoError(t, cfg.KeepLegacyDBPassword())
+	creds, err = newTestConfig(other, "").Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, generated.DBPassword, creds.DBPassword)
+
+	// neither is a configured one
+	t.Setenv("JUDO_DB_PASSWORD", "s3cret")
+	cfg = newTestConfig(t.TempDir(), "")
+	cfg.loadProperties()
+	assert.NoError(t, cfg.KeepLegacyDBPassword())
+	assert.NoFileExists(t, cfg.SecretsPath())
+}
+
+func TestReloadSwitchesProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "judo.properties"), []byte("karaf_port=8282\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "judo-version.properties"), []byte(""), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dev.properties"), []byte("karaf_port=8383\n"), 0o644))
+
+	oldDir, oldProfile := ProjectDir, Profile
+	t.Cleanup(func() {
+		ProjectDir, Profile = oldDir, oldProfile
+		instance = nil
+	})
+
+	assert.Equal(t, []string{"judo", "dev"}, AvailableProfiles(dir))
+
+	ProjectDir, Profile = dir, "judo"
+	assert.Equal(t, 8282, Reload().KarafPort)
+
+	Profile = "dev"
+	cfg := Reload()
+	assert.Equal(t, 8383, cfg.KarafPort)
+	assert.Same(t, cfg, GetConfig())
+}
+
+func TestEmptyValueResetsToDefault(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "judo.properties"), []byte("karaf_port=8282\ncompose_access_ip=10.0.0.1\ndbtype=postgresql\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dev.properties"), []byte("karaf_port=\n"), 0o644))
+	t.Setenv("JUDO_COMPOSE_ACCESS_IP", "")
+
+	oldDir, oldProfile := ProjectDir, Profile
+	t.Cleanup(func() {
+		ProjectDir, Profile = oldDir, oldProfile
+		instance = nil
+	})
+	ProjectDir, Profile = dir, "dev"
+	cfg := Reload()
+	assert.Equal(t, 8181, cfg.KarafPort)
+	assert.Equal(t, LayerProfile, cfg.Origin("karaf_port").Layer)
+	assert.Equal(t, "", cfg.ComposeAccessIP)
+	assert.Equal(t, "postgresql", cfg.DBType)
+
+	assert.NoError(t, ApplyInlineOptions("dbtype="))
+	assert.Equal(t, "hsqldb", cfg.DBType)
+	assert.Equal(t, LayerOptions, cfg.Origin("dbtype").Layer)
+}
+
+func TestImageVersions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "judo-version.properties"), []byte("judo.version=1.0.0\npostgresql.version=14\nkeycloak.version=21.1.1\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "judo.properties"), []byte("keycloak_image=registry.local/keycloak:22.0\n"), 0o644))
+
+	cfg := newTestConfig(dir, "")
+	cfg.PostgresImage, cfg.KeycloakImage = "postgres", "quay.io/keycloak/keycloak"
+	cfg.loadProperties()
+
+	assert.Empty(t, cfg.Problems())
+	assert.Equal(t, "postgres:14", cfg.PostgresImageRef())
+	assert.Equal(t, Origin{Layer: LayerVersions, Location: filepath.Join(dir, "judo-version.properties"), Line: 2}, cfg.Origin("postgres_version"))
+	// an explicit tag wins over the pinned version
+	assert.Equal(t, "registry.local/keycloak:22.0", cfg.KeycloakImageRef())
+
+	assert.Equal(t, "postgres@sha256:abc", imageRef("postgres@sha256:abc", "16.2"))
+	assert.Equal(t, "localhost:5000/postgres:16.2", imageRef("localhost:5000/postgres", "16.2"))
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ContainerSettings are the per-service tuning options applied when the
+// PostgreSQL or Keycloak container is created.
+type ContainerSettings struct {
+	Memory string   `json:"memory,omitempty"` // e.g. 512m, 2g
+	CPUs   string   `json:"cpus,omitempty"`   // e.g. 1.5
+	Env    []string `json:"env,omitempty"`    // KEY=VALUE
+	Args   []string `json:"args,omitempty"`   // appended to the container command
+	Mounts []string `json:"mounts,omitempty"` // host:container[:ro]
+}
+
+// PostgresSettings returns the configured settings of the PostgreSQL container.
+func (c *Config) PostgresSettings() ContainerSettings {
+	return containerSettings(c.PostgresMemory, c.PostgresCPUs, c.PostgresEnv, c.PostgresArgs, c.PostgresMounts)
+}
+
+// KeycloakSettings returns the configured settings of the Keycloak container.
+func (c *Config) KeycloakSettings() ContainerSettings {
+	return containerSettings(c.KeycloakMemory, c.KeycloakCPUs, c.KeycloakEnv, c.KeycloakArgs, c.KeycloakMounts)
+}
+
+func containerSettings(memory, cpus, env, args, mounts string) ContainerSettings {
+	return ContainerSettings{
+		Memory: memory,
+		CPUs:   cpus,
+		Env:    strings.Fields(env),
+		Args:   strings.Fields(args),
+		Mounts: strings.Fields(mounts),
+	}
+}
+
+// Binds returns the extra mounts as Docker binds, with relative host paths
+// resolved against root.
+func (s ContainerSettings) Binds(root string) []string {
+	binds := make([]string, 0, len(s.Mounts))
+	for _, m := range s.Mounts {
+		host, rest, _ := strings.Cut(m, ":")
+		if !filepath.IsAbs(host) {
+			host = filepath.Join(root, host)
+		}
+		binds = append(binds, host+":"+rest)
+	}
+	return binds
+}
+
+var memorySize = regexp.MustCompile(`^([0-9]+)([bBkKmMgG]?)$`)
+
+// ParseMemory converts a size like 512m or 2g into bytes; empty means no limit.
+func ParseMemory(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	m := memorySize.FindStringSubmatch(v)
+	if m == nil {
+		return 0, fmt.Errorf("%q is not a memory size like 512m or 2g", v)
+	}
+	n, _ := strconv.ParseInt(m[1], 10, 64)
+	switch strings.ToLower(m[2]) {
+	case "k":
+		n <<= 10
+	case "m":
+		n <<= 20
+	case "g":
+		n <<= 30
+	}
+	return n, nil
+}
+
+// ParseCPUs converts a CPU count like 1.5 into nano CPUs; empty means no limit.
+func ParseCPUs(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f <= 0 {
+		return 0, fmt.Errorf("%q is not a positive number of CPUs like 1 or 1.5", v)
+	}
+	return int64(f * 1e9), nil
+}
+
+func checkMemory(v string) error {
+	_, err := ParseMemory(v)
+	return err
+}
+
+func checkCPUs(v string) error {
+	_, err := ParseCPUs(v)
+	return err
+}
+
+func checkEnvList(v string) error {
+	for _, kv := range strings.Fields(v) {
+		if k, _, ok := strings.Cut(kv, "="); !ok || k == "" {
+			return fmt.Errorf("%q is not a KEY=VALUE pair", kv)
+		}
+	}
+	return nil
+}
+
+func checkMounts(v string) error {
+	for _, m := range strings.Fields(v) {
+		parts := strings.Split(m, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || !strings.HasPrefix(parts[1], "/") {
+			return fmt.Errorf("%q is not a mount like ./conf:/etc/conf[:ro]", m)
+		}
+		if len(parts) == 3 && parts[2] != "ro" && parts[2] != "rw" {
+			return fmt.Errorf("%q: mount mode must be ro or rw", m)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemory(t *testing.T) {
+	n, err := ParseMemory("512m")
+	require.NoError(t, err)
+	assert.Equal(t, int64(512<<20), n)
+
+	n, err = ParseMemory("2G")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2<<30), n)
+
+	_, err = ParseMemory("lots")
+	assert.Error(t, err)
+}
+
+func TestParseCPUs(t *testing.T) {
+	n, err := ParseCPUs("1.5")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_500_000_000), n)
+
+	_, err = ParseCPUs("0")
+	assert.Error(t, err)
+}
+
+func TestContainerSettingsFromConfig(t *testing.T) {
+	c := &Config{
+		PostgresMemory: "1g",
+		PostgresEnv:    "TZ=UTC  PGTZ=UTC",
+		PostgresArgs:   "-c log_statement=all -c max_connections=200",
+		PostgresMounts: "./db/init:/docker-entrypoint-initdb.d:ro /tmp/x:/x",
+	}
+	s := c.PostgresSettings()
+	assert.Equal(t, []string{"TZ=UTC", "PGTZ=UTC"}, s.Env)
+	assert.Equal(t, []string{"-c", "log_statement=all", "-c", "max_connections=200"}, s.Args)
+	assert.Equal(t, []string{"/project/db/init:/docker-entrypoint-initdb.d:ro", "/tmp/x:/x"}, s.Binds("/project"))
+}
+
+func TestContainerSettingKeysAreValidated(t *testing.T) {
+	assert.NoError(t, Validate("postgres_memory", "512m"))
+	assert.Error(t, Validate("keycloak_cpus", "many"))
+	assert.Error(t, Validate("postgres_env", "TZ"))
+	assert.Error(t, Validate("keycloak_mounts", "./conf"))
+	assert.Error(t, Validate("postgres_mounts", "./conf:/etc/conf:rx"))
+	assert.NoError(t, Validate("postgres_mounts", "./conf:/etc/conf:ro"))
+}
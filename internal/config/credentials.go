@@ -0,0 +1,127 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+)
+
+// Credentials are the database and Keycloak logins of a project.
+type Credentials struct {
+	DBUser                string
+	DBPassword            string
+	KeycloakAdminUser     string
+	KeycloakAdminPassword string
+}
+
+// PGEnv returns the credentials as environment entries for the pg tools.
+func (c Credentials) PGEnv() []string {
+	return []string{"PGUSER=" + c.DBUser, "PGPASSWORD=" + c.DBPassword}
+}
+
+// Credentials resolves the configured credentials. Passwords that are not set
+// through any configuration layer are generated once per project and kept in
+// .judo/secrets.properties, readable by the owner only.
+func (c *Config) Credentials() (Credentials, error) {
+	creds := Credentials{
+		DBUser:                c.DBUser,
+		DBPassword:            c.DBPassword,
+		KeycloakAdminUser:     "admin",
+		KeycloakAdminPassword: c.KeycloakAdminPassword,
+	}
+	if creds.DBUser == "" {
+		creds.DBUser = c.SchemaName
+	}
+	if creds.DBPassword != "" && creds.KeycloakAdminPassword != "" {
+		return creds, nil
+	}
+
+	doc, err := LoadDocument(c.SecretsPath())
+	if err != nil {
+		return creds, fmt.Errorf("failed to read %s: %w", c.SecretsPath(), err)
+	}
+	changed := false
+	for _, s := range []struct {
+		key   string
+		value *string
+	}{
+		{"db_password", &creds.DBPassword},
+		{"keycloak_admin_password", &creds.KeycloakAdminPassword},
+	} {
+		if *s.value != "" {
+			continue
+		}
+		if v, ok := doc.Get(s.key); ok && v != "" {
+			*s.value = v
+			continue
+		}
+		v, err := generateSecret()
+		if err != nil {
+			return creds, err
+		}
+		doc.Set(s.key, v)
+		*s.value = v
+		changed = true
+	}
+	if changed {
+		if err := doc.savePrivate(); err != nil {
+			return creds, fmt.Errorf("failed to write %s: %w", doc.Path, err)
+		}
+	}
+	return creds, nil
+}
+
+// KeepLegacyDBPassword records schema_name, the password earlier versions
+// initialized the database with, as the project's db_password unless one is
+// configured or was generated already. Call it when the database volume
+// exists, since PostgreSQL ignores POSTGRES_PASSWORD for an initialized data
+// directory.
+func (c *Config) KeepLegacyDBPassword() error {
+	if c.DBPassword != "" {
+		return nil
+	}
+	doc, err := LoadDocument(c.SecretsPath())
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", c.SecretsPath(), err)
+	}
+	if v, ok := doc.Get("db_password"); ok && v != "" {
+		return nil
+	}
+	doc.Set("db_password", c.SchemaName)
+	if err := doc.savePrivate(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", doc.Path, err)
+	}
+	return nil
+}
+
+// SecretsPath is the file holding the generated passwords of the project.
+func (c *Config) SecretsPath() string {
+	return filepath.Join(c.projectDir, ".judo", "secrets.properties")
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate password: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Mask hides the value of secret keys in output.
+func Mask(key, value string) string {
+	if value == "" || !IsSecret(key) {
+		return value
+	}
+	return "********"
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package config
+
+import "strings"
+
+// PostgresImageRef returns the PostgreSQL image to run.
+func (c *Config) PostgresImageRef() string {
+	return imageRef(c.PostgresImage, c.PostgresVersion)
+}
+
+// KeycloakImageRef returns the Keycloak image to run.
+func (c *Config) KeycloakImageRef() string {
+	return imageRef(c.KeycloakImage, c.KeycloakVersion)
+}
+
+// imageRef appends the version as tag unless the image already carries a tag
+// or is pinned by digest.
+func imageRef(image, version string) string {
+	if strings.Contains(image, "@") {
+		return image
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if strings.Contains(name, ":") || version == "" {
+		return image
+	}
+	return image + ":" + version
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"judo-cli-module/internal/utils"
+)
+
+// includeDirective pulls the entries of another properties file in at the
+// position of the directive. Relative paths are resolved against the
+// directory of the including file.
+const includeDirective = "include"
+
+// resolver reads a properties file with its includes and expands ${key},
+// ${env:VAR} and ${env:VAR:-default} references in the values.
+type resolver struct {
+	layer      string
+	fallback   func(key string) (string, bool) // value from lower layers
+	entries    []propertyEntry
+	problems   []Problem
+	referenced map[string]bool // keys used by ${key} references
+}
+
+// resolveProperties returns the expanded entries of path together with every
+// include or interpolation problem, each pointing at the offending line.
+// Helper keys, unknown keys that other values reference, are left out of the
+// result: they only exist as interpolation sources.
+func resolveProperties(path, layer string, fallback func(key string) (string, bool)) ([]propertyEntry, []Problem) {
+	r := &resolver{layer: layer, fallback: fallback, referenced: map[string]bool{}}
+	r.read(path, nil, Origin{})
+	if len(r.entries) == 0 {
+		return nil, r.problems
+	}
+
+	resolved := make([]propertyEntry, 0, len(r.entries))
+	for _, e := range r.entries {
+		v, err := r.expand(e.Value, []string{canonical(e.Key)})
+		if err != nil {
+			r.problems = append(r.problems, Problem{Origin: r.origin(e), Key: e.Key, Message: err.Error()})
+			continue
+		}
+		e.Value = v
+		resolved = append(resolved, e)
+	}
+	entries := resolved[:0]
+	for _, e := range resolved {
+		if _, known := CanonicalKey(e.Key); known || !r.referenced[e.Key] {
+			entries = append(entries, e)
+		}
+	}
+	return entries, r.problems
+}
+
+func (r *resolver) origin(e propertyEntry) Origin {
+	return Origin{Layer: r.layer, Location: e.File, Line: e.Line}
+}
+
+// read appends the entries of path, following include directives. stack
+// holds the files currently being read to detect include cycles; from is the
+// include line that referenced path.
+func (r *resolver) read(path string, stack []string, from Origin) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	for _, p := range stack {
+		if p == abs {
+			chain := append(append([]string{}, stack...), abs)
+			r.problems = append(r.problems, Problem{Origin: from, Key: includeDirective,
+				Message: fmt.Sprintf("include cycle: %s", strings.Join(chain, " -> "))})
+			return
+		}
+	}
+
+	if len(stack) > 0 && !utils.FileExists(path) {
+		r.problems = append(r.problems, Problem{Origin: from, Key: includeDirective,
+			Message: fmt.Sprintf("included file %s does not exist", path)})
+		return
+	}
+	d, err := LoadDocument(path)
+	if err != nil {
+		if len(stack) == 0 {
+			from = Origin{Layer: r.layer, Location: path}
+		}
+		r.problems = append(r.problems, Problem{Origin: from, Message: err.Error()})
+		return
+	}
+
+	stack = append(stack, abs)
+	for _, e := range d.Entries() {
+		e.File = path
+		if e.Key != includeDirective {
+			r.entries = append(r.entries, e)
+			continue
+		}
+		target, err := r.expand(e.Value, nil)
+		if err != nil {
+			r.problems = append(r.problems, Problem{Origin: r.origin(e), Key: e.Key, Message: err.Error()})
+			continue
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		r.read(target, stack, r.origin(e))
+	}
+}
+
+// expand replaces every ${...} reference in value. stack holds the keys being
+// expanded to detect reference cycles. References may nest in the default of
+// an environment reference, e.g. ${env:PORT:-${base_port}}.
+func (r *resolver) expand(value string, stack []string) (string, error) {
+	var b strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			b.WriteString(value)
+			return b.String(), nil
+		}
+		end := closingBrace(value[start+2:])
+		if end < 0 {
+			return "", fmt.Errorf("unterminated reference in %q", value)
+		}
+		end += start + 2
+		b.WriteString(value[:start])
+
+		v, err := r.reference(value[start+2:end], stack)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(v)
+		value = value[end+1:]
+	}
+}
+
+func (r *resolver) reference(ref string, stack []string) (string, error) {
+	if name, ok := strings.CutPrefix(ref, "env:"); ok {
+		name, def, hasDefault := strings.Cut(name, ":-")
+		if v := os.Getenv(name); v != "" {
+			return v, nil
+		}
+		if hasDefault {
+			return r.expand(def, stack)
+		}
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	if strings.Contains(ref, "${") {
+		return "", fmt.Errorf("nested reference in key name ${%s}", ref)
+	}
+	key := canonical(ref)
+	for _, k := range stack {
+		if k == key {
+			return "", fmt.Errorf("reference cycle: %s -> %s", strings.Join(stack, " -> "), key)
+		}
+	}
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		if canonical(r.entries[i].Key) == key {
+			r.referenced[key] = true
+			return r.expand(r.entries[i].Value, append(stack, key))
+		}
+	}
+	if r.fallback != nil {
+		if v, ok := r.fallback(key); ok {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("undefined reference ${%s}", ref)
+}
+
+// closingBrace returns the index of the } that closes a reference whose
+// body starts s, skipping the braces of nested references, or -1.
+func closingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "${"):
+			depth++
+			i++
+		case s[i] == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// canonical returns the canonical spelling of known keys and the key itself
+// for helper keys that are only used as interpolation sources.
+func canonical(key string) string {
+	if c, ok := CanonicalKey(key); ok {
+		return c
+	}
+	return key
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestResolvePropertiesInterpolation(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SHOP_PORT", "9090")
+	writeFile(t, filepath.Join(dir, "judo.properties"),
+		"schema_name=${app_name}_db\nkeycloak_name=${schema_name}\nkaraf_port=${env:SHOP_PORT}\ncompose_env=${env:SHOP_ENV:-compose-develop}\n")
+
+	entries, problems := resolveProperties(filepath.Join(dir, "judo.properties"), LayerProject, func(key string) (string, bool) {
+		if key == "app_name" {
+			return "shop", true
+		}
+		return "", false
+	})
+
+	assert.Empty(t, problems)
+	values := map[string]string{}
+	for _, e := range entries {
+		values[e.Key] = e.Value
+	}
+	assert.Equal(t, map[string]string{
+		"schema_name":   "shop_db",
+		"keycloak_name": "shop_db",
+		"karaf_port":    "9090",
+		"compose_env":   "compose-develop",
+	}, values)
+}
+
+func TestHelperKeysAreNotProblems(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "judo.properties"), "base_port=8100\nkaraf_port=${base_port}\nunused_key=1\n")
+
+	cfg := newTestConfig(dir, "")
+	cfg.loadProperties()
+	assert.Equal(t, 8100, cfg.KarafPort)
+	problems := cfg.Problems()
+	if assert.Len(t, problems, 1, "only the unreferenced unknown key is reported") {
+		assert.Equal(t, "unused_key", problems[0].Key)
+	}
+
+	writeFile(t, filepath.Join(dir, "judo.properties"), "base_port=8100\nkaraf_port=${base_port}\n")
+	cfg = newTestConfig(dir, "")
+	cfg.loadProperties()
+	assert.Empty(t, cfg.Problems())
+}
+
+func TestResolvePropertiesIncludes(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "conf"), 0o755))
+	writeFile(t, filepath.Join(dir, "conf", "ports.properties"), "karaf_port=8282\npostgres_port=5433\n")
+	writeFile(t, filepath.Join(dir, "judo.properties"), "karaf_port=8181\ninclude=conf/ports.properties\npostgres_port=5434\n")
+
+	entries, problems := resolveProperties(filepath.Join(dir, "judo.properties"), LayerProject, nil)
+
+	assert.Empty(t, problems)
+	assert.Equal(t, []propertyEntry{
+		{Key: "karaf_port", Value: "8181", File: filepath.Join(dir, "judo.properties"), Line: 1},
+		{Key: "karaf_port", Value: "8282", File: filepath.Join(dir, "conf", "ports.properties"), Line: 1},
+		{Key: "postgres_port", Value: "5433", File: filepath.Join(dir, "conf", "ports.properties"), Line: 2},
+		{Key: "postgres_port", Value: "5434", File: filepath.Join(dir, "judo.properties"), Line: 3},
+	}, entries)
+}
+
+func TestResolvePropertiesErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.properties"), "include=b.properties\n")
+	writeFile(t, filepath.Join(dir, "b.properties"), "app_name=x\ninclude=a.properties\n")
+	writeFile(t, filepath.Join(dir, "judo.properties"),
+		"include=a.properties\ninclude=missing.properties\nschema_name=${keycloak_name}\nkeycloak_name=${schema_name}\nruntime=${nope}\nkaraf_port=${env:JUDO_TEST_UNSET_VAR}\n")
+
+	_, problems := resolveProperties(filepath.Join(dir, "judo.properties"), LayerProject, nil)
+
+	var messages []string
+	for _, p := range problems {
+		messages = append(messages, p.String())
+	}
+	assert.Len(t, messages, 6)
+	assert.Contains(t, messages[0], "b.properties:2: include cycle:")
+	assert.Contains(t, messages[1], "judo.properties:2: included file")
+	assert.Contains(t, messages[2], "judo.properties:3: reference cycle: schema_name -> keycloak_name -> schema_name")
+	assert.Contains(t, messages[3], "judo.properties:4: reference cycle: keycloak_name -> schema_name -> keycloak_name")
+	assert.Contains(t, messages[4], "judo.properties:5: undefined reference ${nope}")
+	assert.Contains(t, messages[5], "judo.properties:6: environment variable JUDO_TEST_UNSET_VAR is not set")
+}
+
+func TestResolvePropertiesNestedReferences(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SHOP_ENV", "")
+	writeFile(t, filepath.Join(dir, "judo.properties"),
+		"base_port=8100\nkaraf_port=${env:SHOP_PORT_UNSET:-${base_port}}\ncompose_env=${env:SHOP_ENV:-compose-${app_name}}\nschema_name=${app_${x}}\nruntime=${env:SHOP_RUNTIME_UNSET:-${base_port}\n")
+
+	entries, problems := resolveProperties(filepath.Join(dir, "judo.properties"), LayerProject, func(key string) (string, bool) {
+		if key == "app_name" {
+			return "shop", true
+		}
+		return "", false
+	})
+
+	values := map[string]string{}
+	for _, e := range entries {
+		values[e.Key] = e.Value
+	}
+	assert.Equal(t, "8100", values["karaf_port"])
+	assert.Equal(t, "compose-shop", values["compose_env"])
+
+	var messages []string
+	for _, p := range problems {
+		messages = append(messages, p.String())
+	}
+	if assert.Len(t, messages, 2) {
+		assert.Contains(t, messages[0], "judo.properties:4: nested reference in key name ${app_${x}}")
+		assert.Contains(t, messages[1], "judo.properties:5: unterminated reference")
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kinds of changes reported by Migrate.
+const (
+	MigrationRenamed  = "renamed"
+	MigrationDropped  = "dropped"
+	MigrationUnmapped = "unmapped"
+)
+
+// MigrationChange describes one entry Migrate rewrote or could not map.
+type MigrationChange struct {
+	Kind    string
+	Line    int // line number in the original file
+	Key     string
+	Message string
+}
+
+func (m MigrationChange) String() string {
+	return fmt.Sprintf("line %d: %s", m.Line, m.Message)
+}
+
+// Migrate rewrites d into the canonical form: aliased keys (e.g. karaf.port)
+// are renamed in place to their canonical spelling and entries overridden by
+// a later occurrence of the same key, with no include line in between, are
+// removed. Unknown keys are left untouched and reported.
+func Migrate(d *Document) []MigrationChange {
+	var changes []MigrationChange
+	lineNo := make([]int, len(d.lines))
+
+	for i := range d.lines {
+		lineNo[i] = i + 1
+		l := &d.lines[i]
+		if l.key == "" || l.key == includeDirective {
+			continue
+		}
+		canonical, ok := CanonicalKey(l.key)
+		switch {
+		case !ok:
+			msg := fmt.Sprintf("%s could not be mapped to a supported key", l.key)
+			if s := Suggest(l.key); s != "" {
+				msg += fmt.Sprintf(" (did you mean %s?)", s)
+			}
+			changes = append(changes, MigrationChange{Kind: MigrationUnmapped, Line: i + 1, Key: l.key, Message: msg})
+		case canonical != l.key:
+			changes = append(changes, MigrationChange{
+				Kind:    MigrationRenamed,
+				Line:    i + 1,
+				Key:     l.key,
+				Message: fmt.Sprintf("renamed %s to %s", l.key, canonical),
+			})
+			l.prefix = strings.Replace(l.prefix, l.key, canonical, 1)
+			l.key = canonical
+			l.raw = l.prefix + l.value
+		}
+	}
+
+	// Keep only the last occurrence of each key within an include segment.
+	// An include line between two occurrences may set the key as well, so
+	// which one is effective depends on the include and both are kept.
+	type occurrence struct {
+		segment int
+		key     string
+	}
+	segments := make([]int, len(d.lines))
+	last := map[occurrence]int{}
+	segment := 0
+	for i, l := range d.lines {
+		if l.key == includeDirective {
+			segment++
+		}
+		segments[i] = segment
+		if l.key != "" && l.key != includeDirective {
+			last[occurrence{segment, l.key}] = i
+		}
+	}
+	kept := d.lines[:0]
+	for i, l := range d.lines {
+		if j, ok := last[occurrence{segments[i], l.key}]; ok && j != i {
+			if _, known := lookupProperty(l.key); known {
+				changes = append(changes, MigrationChange{
+					Kind:    MigrationDropped,
+					Line:    lineNo[i],
+					Key:     l.key,
+					Message: fmt.Sprintf("removed %s, overridden on line %d", l.key, lineNo[j]),
+				})
+				continue
+			}
+		}
+		kept = append(kept, l)
+	}
+	d.lines = kept
+	return changes
+}
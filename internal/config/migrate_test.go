@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	src := "# app\napp.name = shop\nkaraf.port=8080\ndatabase.username=judo\nkaraf_port=8181\n"
+	d, err := ParseDocument(strings.NewReader(src))
+	assert.NoError(t, err)
+
+	changes := Migrate(d)
+
+	assert.Equal(t, "# app\napp_name = shop\ndatabase.username=judo\nkaraf_port=8181\n", string(d.Bytes()))
+	assert.Equal(t, []MigrationChange{
+		{Kind: MigrationRenamed, Line: 2, Key: "app.name", Message: "renamed app.name to app_name"},
+		{Kind: MigrationRenamed, Line: 3, Key: "karaf.port", Message: "renamed karaf.port to karaf_port"},
+		{Kind: MigrationUnmapped, Line: 4, Key: "database.username", Message: "database.username could not be mapped to a supported key"},
+		{Kind: MigrationDropped, Line: 3, Key: "karaf_port", Message: "removed karaf_port, overridden on line 5"},
+	}, changes)
+}
+
+func TestMigrateKeepsDuplicatesAcrossIncludes(t *testing.T) {
+	src := "karaf_port=8080\ninclude=ports.properties\nkaraf.port=8181\nkaraf_port=8282\n"
+	d, err := ParseDocument(strings.NewReader(src))
+	assert.NoError(t, err)
+
+	changes := Migrate(d)
+
+	// the first karaf_port stays: whether ports.properties overrides it
+	// depends on the include position, only the duplicate after it is dropped
+	assert.Equal(t, "karaf_port=8080\ninclude=ports.properties\nkaraf_port=8282\n", string(d.Bytes()))
+	assert.Equal(t, []MigrationChange{
+		{Kind: MigrationRenamed, Line: 3, Key: "karaf.port", Message: "renamed karaf.port to karaf_port"},
+		{Kind: MigrationDropped, Line: 3, Key: "karaf_port", Message: "removed karaf_port, overridden on line 4"},
+	}, changes)
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package config
+
+// ResourceNames are the names of the Docker containers, volumes and network
+// of a project. Every command takes them from here, so start, status, stop,
+// clean, dump and import always address the same resources.
+type ResourceNames struct {
+	PostgresContainer  string // postgres-<schema_name>
+	KeycloakContainer  string // keycloak-<keycloak_name>
+	Network            string // <app_name>
+	PostgresDBVolume   string // <schema_name>_postgresql_db
+	PostgresDataVolume string // <schema_name>_postgresql_data
+	CertsVolume        string // <app_name>_certs
+	FilestoreVolume    string // <app_name>_filestore
+
+	// LegacyPostgresVolumes are the PostgreSQL volume names of released
+	// versions, which carry no labels: <app_name>_postgresql_db and
+	// <app_name>_postgresql_data.
+	LegacyPostgresVolumes []string
+}
+
+// Names returns the resource names derived from the configuration.
+func (c *Config) Names() ResourceNames {
+	return ResourceNames{
+		PostgresContainer:  "postgres-" + c.SchemaName,
+		KeycloakContainer:  "keycloak-" + c.KeycloakName,
+		Network:            c.AppName,
+		PostgresDBVolume:   c.SchemaName + "_postgresql_db",
+		PostgresDataVolume: c.SchemaName + "_postgresql_data",
+		CertsVolume:        c.AppName + "_certs",
+		FilestoreVolume:    c.AppName + "_filestore",
+
+		LegacyPostgresVolumes: []string{c.AppName + "_postgresql_db", c.AppName + "_postgresql_data"},
+	}
+}
+
+// PostgresVolumes returns the PostgreSQL volumes in mount order.
+func (n ResourceNames) PostgresVolumes() []string {
+	return []string{n.PostgresDBVolume, n.PostgresDataVolume}
+}
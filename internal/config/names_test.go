@@ -0,0 +1,19 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNames(t *testing.T) {
+	c := &Config{AppName: "shop", SchemaName: "shop_db", KeycloakName: "shop-kc"}
+	n := c.Names()
+	assert.Equal(t, "postgres-shop_db", n.PostgresContainer)
+	assert.Equal(t, "keycloak-shop-kc", n.KeycloakContainer)
+	assert.Equal(t, "shop", n.Network)
+	assert.Equal(t, []string{"shop_db_postgresql_db", "shop_db_postgresql_data"}, n.PostgresVolumes())
+	assert.Equal(t, "shop_certs", n.CertsVolume)
+	assert.Equal(t, "shop_filestore", n.FilestoreVolume)
+	assert.Equal(t, []string{"shop_postgresql_db", "shop_postgresql_data"}, n.LegacyPostgresVolumes)
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package config
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Document is a properties file kept line by line, so it can be edited and
+// written back without losing comments, blank lines, ordering or the
+// separator style of existing entries.
+type Document struct {
+	Path  string
+	lines []docLine
+}
+
+type docLine struct {
+	raw    string
+	key    string // empty for comments and blank lines
+	value  string
+	prefix string // indentation, key and separator exactly as written
+	sep    string // separator including surrounding whitespace, e.g. " = "
+}
+
+// LoadDocument reads a properties file. A missing file yields an empty
+// document that will be created on Save.
+func LoadDocument(path string) (*Document, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return &Document{Path: path}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	d, err := ParseDocument(f)
+	if err != nil {
+		return nil, err
+	}
+	d.Path = path
+	return d, nil
+}
+
+// ParseDocument parses properties content from r.
+func ParseDocument(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	d := &Document{}
+	if len(data) == 0 {
+		return d, nil
+	}
+	text := strings.TrimSuffix(string(data), "\n")
+	for _, raw := range strings.Split(text, "\n") {
+		d.lines = append(d.lines, parseLine(strings.TrimSuffix(raw, "\r")))
+	}
+	return d, nil
+}
+
+func parseLine(raw string) docLine {
+	l := docLine{raw: raw}
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "!") {
+		return l
+	}
+	i := strings.IndexAny(raw, "=:")
+	if i < 0 {
+		return l
+	}
+	key := strings.TrimSpace(raw[:i])
+	if key == "" {
+		return l
+	}
+	rest := raw[i+1:]
+	value := strings.TrimLeft(rest, " \t")
+	valueStart := i + 1 + len(rest) - len(value)
+
+	keyEnd := strings.Index(raw, key) + len(key)
+	l.key = key
+	l.value = strings.TrimSpace(value)
+	l.prefix = raw[:valueStart]
+	l.sep = raw[keyEnd:valueStart]
+	return l
+}
+
+// Get returns the value of key. When a key is repeated the last one wins.
+func (d *Document) Get(key string) (string, bool) {
+	if i := d.index(key); i >= 0 {
+		return d.lines[i].value, true
+	}
+	return "", false
+}
+
+// Set updates the last occurrence of key in place, or appends a new entry
+// using the separator style already used in the document.
+func (d *Document) Set(key, value string) {
+	if i := d.index(key); i >= 0 {
+		l := &d.lines[i]
+		l.value = value
+		l.raw = l.prefix + value
+		return
+	}
+	sep := d.separator()
+	d.lines = append(d.lines, docLine{
+		raw:    key + sep + value,
+		key:    key,
+		value:  value,
+		prefix: key + sep,
+		sep:    sep,
+	})
+}
+
+// Unset removes every occurrence of key and reports whether any was found.
+func (d *Document) Unset(key string) bool {
+	found := false
+	kept := d.lines[:0]
+	for _, l := range d.lines {
+		if l.key == key {
+			found = true
+			continue
+		}
+		kept = append(kept, l)
+	}
+	d.lines = kept
+	return found
+}
+
+// SetProperty sets a configuration key in whichever spelling (canonical or
+// alias) the document already uses for it, removing the other spellings so
+// no conflicting entry is left. A new entry uses the canonical key.
+func (d *Document) SetProperty(key, value string) {
+	names := spellings(key)
+	keep := ""
+	for _, l := range d.lines {
+		if slices.Contains(names, l.key) {
+			keep = l.key
+		}
+	}
+	if keep == "" {
+		d.Set(names[0], value)
+		return
+	}
+	for _, n := range names {
+		if n != keep {
+			d.Unset(n)
+		}
+	}
+	d.Set(keep, value)
+}
+
+// UnsetProperty removes a configuration key in every spelling and reports
+// whether any was found.
+func (d *Document) UnsetProperty(key string) bool {
+	found := false
+	for _, n := range spellings(key) {
+		if d.Unset(n) {
+			found = true
+		}
+	}
+	return found
+}
+
+// spellings returns the canonical key followed by its aliases; an unknown
+// key is only spelled as given.
+func spellings(key string) []string {
+	p, ok := lookupProperty(key)
+	if !ok {
+		return []string{key}
+	}
+	return append([]string{p.Name}, p.Aliases...)
+}
+
+// Entries returns all key/value pairs with their 1-based line numbers.
+func (d *Document) Entries() []propertyEntry {
+	var entries []propertyEntry
+	for i, l := range d.lines {
+		if l.key != "" {
+			entries = append(entries, propertyEntry{Key: l.key, Value: l.value, Line: i + 1})
+		}
+	}
+	return entries
+}
+
+// Bytes renders the document.
+func (d *Document) Bytes() []byte {
+	var b bytes.Buffer
+	for _, l := range d.lines {
+		b.WriteString(l.raw)
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// Save writes the document back to its path, creating the parent directory
+// when needed (e.g. ~/.judo). The per-user ~/.judo/config may hold passwords
+// and is readable by the owner only.
+func (d *Document) Save() error {
+	if d.Path == GlobalConfigPath() {
+		return d.savePrivate()
+	}
+	if err := os.MkdirAll(filepath.Dir(d.Path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(d.Path, d.Bytes(), 0o644)
+}
+
+// savePrivate writes the document readable by the owner only.
+func (d *Document) savePrivate() error {
+	if err := os.MkdirAll(filepath.Dir(d.Path), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(d.Path, d.Bytes(), 0o600); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file
+	return os.Chmod(d.Path, 0o600)
+}
+
+func (d *Document) index(key string) int {
+	for i := len(d.lines) - 1; i >= 0; i-- {
+		if d.lines[i].key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (d *Document) separator() string {
+	for _, l := range d.lines {
+		if l.key != "" {
+			return l.sep
+		}
+	}
+	return "="
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentRoundTrip(t *testing.T) {
+	src := "# JUDO project\n\napp_name = shop\nkaraf_port: 8181\n; legacy comment\ndbtype=hsqldb\n"
+	d, err := ParseDocument(strings.NewReader(src))
+	assert.NoError(t, err)
+	assert.Equal(t, src, string(d.Bytes()))
+
+	v, ok := d.Get("karaf_port")
+	assert.True(t, ok)
+	assert.Equal(t, "8181", v)
+}
+
+func TestDocumentEdit(t *testing.T) {
+	d, err := ParseDocument(strings.NewReader("# ports\nkaraf_port : 8181\n\ndbtype = hsqldb\n"))
+	assert.NoError(t, err)
+
+	d.Set("karaf_port", "8282")
+	d.Set("runtime", "compose")
+	assert.True(t, d.Unset("dbtype"))
+	assert.False(t, d.Unset("dbtype"))
+
+	assert.Equal(t, "# ports\nkaraf_port : 8282\n\nruntime : compose\n", string(d.Bytes()))
+	assert.Equal(t, []propertyEntry{
+		{Key: "karaf_port", Value: "8282", Line: 2},
+		{Key: "runtime", Value: "compose", Line: 4},
+	}, d.Entries())
+}
+
+func TestDocumentSetPropertyUsesExistingAlias(t *testing.T) {
+	d, err := ParseDocument(strings.NewReader("karaf.port = 8181\ndbtype = hsqldb\ndatabase.type = postgresql\n"))
+	assert.NoError(t, err)
+
+	d.SetProperty("karaf_port", "8182")
+	d.SetProperty("database.type", "hsqldb")
+	d.SetProperty("runtime.mode", "compose")
+	assert.Equal(t, "karaf.port = 8182\ndatabase.type = hsqldb\nruntime = compose\n", string(d.Bytes()))
+
+	assert.True(t, d.UnsetProperty("karaf_port"))
+	assert.True(t, d.UnsetProperty("dbtype"))
+	assert.False(t, d.UnsetProperty("karaf.port"))
+	assert.Equal(t, "runtime = compose\n", string(d.Bytes()))
+}
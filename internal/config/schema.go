@@ -0,0 +1,375 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Value types of configuration keys.
+const (
+	TypeString = "string"
+	TypeInt    = "int"
+	TypePort   = "port"
+	TypeBool   = "bool"
+	TypeEnum   = "enum"
+	TypePath   = "path"
+)
+
+// property describes a configuration key understood by every input channel
+// (properties files, environment and --options).
+type property struct {
+	Name        string
+	Aliases     []string // documented dotted spellings, e.g. karaf.port
+	Type        string
+	Allowed     []string // for TypeEnum
+	Description string
+	Secret      bool // masked in output
+
+	normalize func(v string) string
+	validate  func(v string) error // extra check on top of the type
+	set       func(c *Config, v string)
+	get       func(c *Config) string
+}
+
+var properties = []property{
+	{
+		Name:        "model_dir",
+		Type:        TypePath,
+		Description: "Model project directory, relative to the project root",
+		set: func(c *Config, v string) {
+			// relative to the project root, so layers setting it do not add up
+			if !filepath.IsAbs(v) {
+				v = filepath.Join(c.projectDir, v)
+			}
+			c.ModelDir = filepath.Clean(v)
+			c.AppDir = filepath.Join(c.ModelDir, "application")
+			c.KarafDir = filepath.Join(c.AppDir, ".karaf")
+		},
+		get: func(c *Config) string { return c.ModelDir },
+	},
+	withAliases(stringProperty("app_name", "Application name", func(c *Config) *string { return &c.AppName }), "app.name"),
+	withAliases(stringProperty("schema_name", "Database schema name", func(c *Config) *string { return &c.SchemaName }), "app.schema", "database.schema"),
+	stringProperty("keycloak_name", "Keycloak container suffix", func(c *Config) *string { return &c.KeycloakName }),
+	withAliases(enumProperty("runtime", "Runtime", []string{"karaf", "compose"}, func(c *Config) *string { return &c.Runtime }), "runtime.mode"),
+	withNormalize(
+		withAliases(enumProperty("dbtype", "Database type", []string{"hsqldb", "postgresql"}, func(c *Config) *string { return &c.DBType }), "database.type"),
+		func(v string) string {
+			if v == "postgres" {
+				return "postgresql"
+			}
+			return v
+		},
+	),
+	stringProperty("compose_env", "Docker compose environment directory", func(c *Config) *string { return &c.ComposeEnv }),
+	stringProperty("compose_access_ip", "Alternate IP address to access the compose app", func(c *Config) *string { return &c.ComposeAccessIP }),
+	withAliases(portProperty("karaf_port", "Karaf HTTP port", func(c *Config) *int { return &c.KarafPort }), "karaf.port"),
+	withAliases(portProperty("postgres_port", "PostgreSQL port", func(c *Config) *int { return &c.PostgresPort }), "postgres.port", "database.port"),
+	withAliases(portProperty("keycloak_port", "Keycloak HTTP port", func(c *Config) *int { return &c.KeycloakPort }), "keycloak.port"),
+	boolProperty("karaf_enable_admin_user", "Enable the karaf admin user", func(c *Config) *bool { return &c.KarafEnableAdminUser }),
+	enumProperty("java_compiler", "Java compiler", []string{"ejc", "javac"}, func(c *Config) *string { return &c.JavaCompiler }),
+	stringProperty("default_profile", "Profile used when --env is not given", func(c *Config) *string { return &c.DefaultProfile }),
+	enumProperty("color", "Colored output", []string{"auto", "always", "never"}, func(c *Config) *string { return &c.Color }),
+	stringProperty("maven_args", "Extra Maven arguments for every build", func(c *Config) *string { return &c.MavenArgs }),
+	withCheck(
+		stringProperty("java_heap", "Karaf JVM heap size (-Xms/-Xmx)", func(c *Config) *string { return &c.JavaHeap }),
+		func(v string) error {
+			if !heapSize.MatchString(v) {
+				return fmt.Errorf("java_heap: %q is not a heap size like 1024m or 2g", v)
+			}
+			return nil
+		},
+	),
+	intProperty("port_offset", "Added to karaf_port, postgres_port and keycloak_port", func(c *Config) *int { return &c.PortOffset }),
+	withCheck(stringProperty("postgres_image", "PostgreSQL image, optionally with :tag or @digest", func(c *Config) *string { return &c.PostgresImage }), checkImage),
+	withAliases(withCheck(stringProperty("postgres_version", "PostgreSQL image tag", func(c *Config) *string { return &c.PostgresVersion }), checkImage), "postgresql.version"),
+	withCheck(stringProperty("keycloak_image", "Keycloak image, optionally with :tag or @digest", func(c *Config) *string { return &c.KeycloakImage }), checkImage),
+	withAliases(withCheck(stringProperty("keycloak_version", "Keycloak image tag", func(c *Config) *string { return &c.KeycloakVersion }), checkImage), "keycloak.version"),
+	withCheck(intProperty("postgres_timeout", "Seconds to wait for PostgreSQL to accept queries", func(c *Config) *int { return &c.PostgresTimeout }), checkPositive),
+	withCheck(intProperty("keycloak_timeout", "Seconds to wait for the Keycloak master realm", func(c *Config) *int { return &c.KeycloakTimeout }), checkPositive),
+	withCheck(intProperty("karaf_timeout", "Seconds to wait for the Karaf HTTP endpoint", func(c *Config) *int { return &c.KarafTimeout }), checkPositive),
+	withCheck(stringProperty("postgres_memory", "PostgreSQL container memory limit, e.g. 1g", func(c *Config) *string { return &c.PostgresMemory }), checkMemory),
+	withCheck(stringProperty("postgres_cpus", "PostgreSQL container CPU limit, e.g. 1.5", func(c *Config) *string { return &c.PostgresCPUs }), checkCPUs),
+	withCheck(stringProperty("postgres_env", "Extra PostgreSQL environment, space separated KEY=VALUE", func(c *Config) *string { return &c.PostgresEnv }), checkEnvList),
+	stringProperty("postgres_args", "Extra PostgreSQL command arguments, e.g. -c log_statement=all", func(c *Config) *string { return &c.PostgresArgs }),
+	withCheck(stringProperty("postgres_mounts", "Extra PostgreSQL bind mounts, space separated host:container[:ro]", func(c *Config) *string { return &c.PostgresMounts }), checkMounts),
+	withCheck(stringProperty("keycloak_memory", "Keycloak container memory limit, e.g. 1g", func(c *Config) *string { return &c.KeycloakMemory }), checkMemory),
+	withCheck(stringProperty("keycloak_cpus", "Keycloak container CPU limit, e.g. 1.5", func(c *Config) *string { return &c.KeycloakCPUs }), checkCPUs),
+	withCheck(stringProperty("keycloak_env", "Extra Keycloak environment, space separated KEY=VALUE", func(c *Config) *string { return &c.KeycloakEnv }), checkEnvList),
+	stringProperty("keycloak_args", "Extra Keycloak command arguments, e.g. --log-level=DEBUG", func(c *Config) *string { return &c.KeycloakArgs }),
+	withCheck(stringProperty("keycloak_mounts", "Extra Keycloak bind mounts, space separated host:container[:ro]", func(c *Config) *string { return &c.KeycloakMounts }), checkMounts),
+	stringProperty("db_user", "Database user (defaults to schema_name)", func(c *Config) *string { return &c.DBUser }),
+	secret(stringProperty("db_password", "Database password (generated when not set)", func(c *Config) *string { return &c.DBPassword })),
+	secret(stringProperty("keycloak_admin_password", "Keycloak admin password (generated when not set)", func(c *Config) *string { return &c.KeycloakAdminPassword })),
+}
+
+var heapSize = regexp.MustCompile(`^[0-9]+[kKmMgG]?$`)
+
+func checkPositive(v string) error {
+	if n, _ := strconv.Atoi(v); n <= 0 {
+		return fmt.Errorf("%q must be a positive number of seconds", v)
+	}
+	return nil
+}
+
+func checkImage(v string) error {
+	if strings.ContainsAny(v, " \t") {
+		return fmt.Errorf("%q is not a valid image reference", v)
+	}
+	return nil
+}
+
+func stringProperty(name, description string, field func(c *Config) *string) property {
+	return property{
+		Name:        name,
+		Type:        TypeString,
+		Description: description,
+		set:         func(c *Config, v string) { *field(c) = v },
+		get:         func(c *Config) string { return *field(c) },
+	}
+}
+
+func enumProperty(name, description string, allowed []string, field func(c *Config) *string) property {
+	p := stringProperty(name, description, field)
+	p.Type = TypeEnum
+	p.Allowed = allowed
+	return p
+}
+
+func portProperty(name, description string, field func(c *Config) *int) property {
+	return property{
+		Name:        name,
+		Type:        TypePort,
+		Description: description,
+		set: func(c *Config, v string) {
+			n, _ := strconv.Atoi(v)
+			*field(c) = n
+		},
+		get: func(c *Config) string { return strconv.Itoa(*field(c)) },
+	}
+}
+
+func intProperty(name, description string, field func(c *Config) *int) property {
+	p := portProperty(name, description, field)
+	p.Type = TypeInt
+	return p
+}
+
+func boolProperty(name, description string, field func(c *Config) *bool) property {
+	return property{
+		Name:        name,
+		Type:        TypeBool,
+		Description: description,
+		set:         func(c *Config, v string) { *field(c) = v == "1" || strings.EqualFold(v, "true") },
+		get:         func(c *Config) string { return strconv.FormatBool(*field(c)) },
+	}
+}
+
+func withAliases(p property, aliases ...string) property {
+	p.Aliases = aliases
+	return p
+}
+
+func secret(p property) property {
+	p.Secret = true
+	return p
+}
+
+func withCheck(p property, validate func(v string) error) property {
+	p.validate = validate
+	return p
+}
+
+func withNormalize(p property, normalize func(v string) string) property {
+	p.normalize = normalize
+	return p
+}
+
+// check validates a raw value against the declared type and returns the
+// normalized value to apply.
+func (p property) check(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if p.normalize != nil {
+		v = p.normalize(v)
+	}
+	switch p.Type {
+	case TypeInt:
+		if _, err := strconv.Atoi(v); err != nil {
+			return "", fmt.Errorf("%s: %q is not a valid integer", p.Name, v)
+		}
+	case TypePort:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return "", fmt.Errorf("%s: %q is not a valid port number", p.Name, v)
+		}
+		if n < 1 || n > 65535 {
+			return "", fmt.Errorf("%s: port %d is out of range 1-65535", p.Name, n)
+		}
+	case TypeBool:
+		switch strings.ToLower(v) {
+		case "1", "0", "true", "false":
+		default:
+			return "", fmt.Errorf("%s: %q is not a boolean (use true/false or 1/0)", p.Name, v)
+		}
+	case TypeEnum:
+		for _, a := range p.Allowed {
+			if v == a {
+				return v, nil
+			}
+		}
+		return "", fmt.Errorf("%s: %q is not one of %s", p.Name, v, strings.Join(p.Allowed, "|"))
+	}
+	if p.validate != nil {
+		if err := p.validate(v); err != nil {
+			return "", err
+		}
+	}
+	return v, nil
+}
+
+// lookupProperty finds a property by its canonical name or one of its aliases.
+func lookupProperty(name string) (property, bool) {
+	for _, p := range properties {
+		if p.Name == name {
+			return p, true
+		}
+		for _, a := range p.Aliases {
+			if a == name {
+				return p, true
+			}
+		}
+	}
+	return property{}, false
+}
+
+// IsSecret reports whether the value of key must not be shown.
+func IsSecret(key string) bool {
+	p, ok := lookupProperty(key)
+	return ok && p.Secret
+}
+
+// CanonicalKey returns the canonical spelling of a key or alias.
+func CanonicalKey(name string) (string, bool) {
+	p, ok := lookupProperty(name)
+	return p.Name, ok
+}
+
+// Validate checks that key is a supported configuration key and that value
+// is acceptable for it.
+func Validate(key, value string) error {
+	p, ok := lookupProperty(key)
+	if !ok {
+		if s := Suggest(key); s != "" {
+			return fmt.Errorf("unknown configuration key %q, did you mean %q?", key, s)
+		}
+		return fmt.Errorf("unknown configuration key %q", key)
+	}
+	_, err := p.check(value)
+	return err
+}
+
+// ValidateRaw is Validate for a value as written to a properties file. A
+// value with ${...} references only has its key checked: loading validates
+// it once the references are expanded.
+func ValidateRaw(key, value string) error {
+	if _, ok := lookupProperty(key); ok && strings.Contains(value, "${") {
+		return nil
+	}
+	return Validate(key, value)
+}
+
+// Suggest returns the known key closest to an unknown one, or "" when nothing
+// is reasonably close.
+func Suggest(key string) string {
+	best, bestDist := "", -1
+	for _, p := range properties {
+		d := editDistance(strings.ToLower(key), p.Name)
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = p.Name, d
+		}
+	}
+	if bestDist <= max(2, len(key)/3) {
+		return best
+	}
+	return ""
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// KeyInfo describes a supported configuration key for help and listings.
+type KeyInfo struct {
+	Name        string
+	Aliases     []string
+	Type        string
+	Allowed     []string
+	Description string
+	Secret      bool
+}
+
+// Schema returns the declared schema of every supported configuration key.
+func Schema() []KeyInfo {
+	infos := make([]KeyInfo, 0, len(properties))
+	for _, p := range properties {
+		infos = append(infos, KeyInfo{Name: p.Name, Aliases: p.Aliases, Type: p.Type, Allowed: p.Allowed, Description: p.Description, Secret: p.Secret})
+	}
+	return infos
+}
+
+// Keys returns the names of all supported configuration keys in display order.
+func Keys() []string {
+	names := make([]string, 0, len(properties))
+	for _, p := range properties {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+const envPrefix = "JUDO_"
+
+// ProfileEnv selects the profile when --env is not given.
+const ProfileEnv = "JUDO_PROFILE"
+
+// EnvName returns the environment variable that overrides the given key.
+func EnvName(key string) string {
+	if p, ok := lookupProperty(key); ok {
+		key = p.Name
+	}
+	return envPrefix + strings.ToUpper(key)
+}
+
+// isReservedEnv reports whether a JUDO_ variable is not a configuration
+// override: the profile selector and the JUDO_PLATFORM_* variables passed to
+// the Karaf runtime.
+func isReservedEnv(name string) bool {
+	return name == ProfileEnv || strings.HasPrefix(name, "JUDO_PLATFORM_")
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("karaf_port", "8181"))
+	assert.NoError(t, Validate("dbtype", "postgres"))
+	assert.NoError(t, Validate("karaf_enable_admin_user", "1"))
+
+	assert.ErrorContains(t, Validate("karaf_port", "70000"), "out of range")
+	assert.ErrorContains(t, Validate("dbtype", "mysql"), "not one of hsqldb|postgresql")
+	assert.ErrorContains(t, Validate("karaf_enable_admin_user", "yes please"), "not a boolean")
+	assert.ErrorContains(t, Validate("app_nam", "x"), `did you mean "app_name"?`)
+	assert.NoError(t, Validate("karaf.port", "8282"))
+	assert.NoError(t, Validate("database.type", "postgresql"))
+}
+
+func TestValidateRaw(t *testing.T) {
+	assert.NoError(t, ValidateRaw("karaf_port", "${base_port}"))
+	assert.ErrorContains(t, ValidateRaw("karaf_port", "70000"), "out of range")
+	assert.ErrorContains(t, ValidateRaw("karaf_prot", "${base_port}"), `did you mean "karaf_port"?`)
+}
+
+func TestSuggest(t *testing.T) {
+	assert.Equal(t, "karaf_port", Suggest("karafport"))
+	assert.Equal(t, "keycloak_port", Suggest("KEYCLOAK_PORT"))
+	assert.Equal(t, "", Suggest("something_else_entirely"))
+}
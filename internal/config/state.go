@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// StatePath is the file holding the ports chosen by 'judo start
+// --auto-ports' for the current profile. It is read as the state layer, so
+// every later command of the profile uses the same ports.
+func (c *Config) StatePath() string {
+	profile := c.Profile
+	if profile == "" {
+		profile = "judo"
+	}
+	return filepath.Join(c.projectDir, ".judo", "state", profile+".properties")
+}
+
+// SavePorts records the current value of the given service ports in the
+// state file. Only moved ports belong there: the state layer outranks the
+// properties files, so a saved port hides later edits of them.
+func (c *Config) SavePorts(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	doc, err := LoadDocument(c.StatePath())
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		v, _ := c.Value(key)
+		doc.Set(key, v)
+	}
+	return doc.Save()
+}
+
+// ClearState removes the state file of the current profile, so the
+// configured ports apply again.
+func (c *Config) ClearState() error {
+	if err := os.Remove(c.StatePath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// SetPort changes a service port of the loaded configuration; see SavePorts.
+func (c *Config) SetPort(key string, port int) error {
+	return c.set(key, strconv.Itoa(port), Origin{Layer: LayerState, Location: c.StatePath()})
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedPortsAreLoadedAsStateLayer(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "judo.properties"), []byte("port_offset=100\n"), 0o644))
+
+	cfg := newTestConfig(dir, "dev")
+	cfg.loadProperties()
+	assert.Equal(t, 8281, cfg.KarafPort)
+
+	require.NoError(t, cfg.SetPort("karaf_port", 8290))
+	require.NoError(t, cfg.SavePorts("karaf_port"))
+	assert.FileExists(t, filepath.Join(dir, ".judo", "state", "dev.properties"))
+
+	// the saved ports already include the offset
+	reloaded := newTestConfig(dir, "dev")
+	reloaded.loadProperties()
+	assert.Equal(t, 8290, reloaded.KarafPort)
+	assert.Equal(t, 8180, reloaded.KeycloakPort)
+	assert.Equal(t, LayerState, reloaded.Origin("karaf_port").Layer)
+	assert.NotEqual(t, LayerState, reloaded.Origin("keycloak_port").Layer, "ports that did not move are not saved")
+
+	// other profiles keep their own ports
+	other := newTestConfig(dir, "")
+	other.loadProperties()
+	assert.Equal(t, 8281, other.KarafPort)
+
+	require.NoError(t, reloaded.ClearState())
+	require.NoError(t, reloaded.ClearState())
+	fresh := newTestConfig(dir, "dev")
+	fresh.loadProperties()
+	assert.Equal(t, 8281, fresh.KarafPort)
+}
+
+func TestSavePortsWithoutMovedPorts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := newTestConfig(t.TempDir(), "")
+	cfg.loadProperties()
+	require.NoError(t, cfg.SavePorts())
+	assert.NoFileExists(t, cfg.StatePath())
+}
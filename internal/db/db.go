@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"judo-cli-module/internal/config"
 	"judo-cli-module/internal/docker"
 	"judo-cli-module/internal/utils"
 	"os"
@@ -12,11 +13,12 @@ import (
 	"github.com/docker/docker/api/types/container"
 )
 
-// DumpPostgresql dumps the PostgreSQL database to a file.
-func DumpPostgresql(containerName, schema string) (string, error) {
+// DumpPostgresql dumps the PostgreSQL database to a file in dir. The password
+// is handed to pg_dump through the exec environment, never on a command line.
+func DumpPostgresql(ctx context.Context, containerName, schema, dir string, creds config.Credentials) (string, error) {
 	cli := docker.GetDockerClient()
 	timestamp := utils.TimeNow().Format("20060102_150405")
-	file := fmt.Sprintf("%s_dump_%s.tar.gz", schema, timestamp)
+	file := filepath.Join(dir, fmt.Sprintf("%s_dump_%s.tar.gz", schema, timestamp))
 
 	out, err := os.Create(file)
 	if err != nil {
@@ -27,18 +29,16 @@ func DumpPostgresql(containerName, schema string) (string, error) {
 	execConfig := container.ExecOptions{
 		AttachStdout: true,
 		AttachStderr: true,
-		Cmd: []string{
-			"/bin/bash", "-c",
-			fmt.Sprintf("PGPASSWORD=%s pg_dump --username=%s -F c %s", schema, schema, schema),
-		},
+		Env:          creds.PGEnv(),
+		Cmd:          []string{"pg_dump", "-F", "c", schema},
 	}
 
-	resp, err := cli.ContainerExecCreate(context.Background(), containerName, execConfig)
+	resp, err := cli.ContainerExecCreate(ctx, containerName, execConfig)
 	if err != nil {
 		return "", err
 	}
 
-	hijackedResponse, err := cli.ContainerExecAttach(context.Background(), resp.ID, container.ExecStartOptions{})
+	hijackedResponse, err := cli.ContainerExecAttach(ctx, resp.ID, container.ExecStartOptions{})
 	if err != nil {
 		return "", err
 	}
@@ -49,7 +49,7 @@ func DumpPostgresql(containerName, schema string) (string, error) {
 }
 
 // ImportPostgresql imports a PostgreSQL database dump.
-func ImportPostgresql(containerName, schema, dumpFile string) error {
+func ImportPostgresql(ctx context.Context, containerName, schema, dumpFile string, creds config.Credentials) error {
 	cli := docker.GetDockerClient()
 	in, err := os.Open(dumpFile)
 	if err != nil {
@@ -61,18 +61,16 @@ func ImportPostgresql(containerName, schema, dumpFile string) error {
 		AttachStdin:  true,
 		AttachStdout: true,
 		AttachStderr: true,
-		Cmd: []string{
-			"/bin/bash", "-c",
-			fmt.Sprintf("PGPASSWORD=%s pg_restore -Fc --clean -U %s -d %s", schema, schema, schema),
-		},
+		Env:          creds.PGEnv(),
+		Cmd:          []string{"pg_restore", "-Fc", "--clean", "-d", schema},
 	}
 
-	resp, err := cli.ContainerExecCreate(context.Background(), containerName, execConfig)
+	resp, err := cli.ContainerExecCreate(ctx, containerName, execConfig)
 	if err != nil {
 		return err
 	}
 
-	hijackedResponse, err := cli.ContainerExecAttach(context.Background(), resp.ID, container.ExecStartOptions{})
+	hijackedResponse, err := cli.ContainerExecAttach(ctx, resp.ID, container.ExecStartOptions{})
 	if err != nil {
 		return err
 	}
@@ -86,9 +84,9 @@ func ImportPostgresql(containerName, schema, dumpFile string) error {
 	return nil
 }
 
-// FindLatestDump finds the latest PostgreSQL dump file for a given schema.
-func FindLatestDump(schema string) (string, error) {
-	pattern := fmt.Sprintf("%s_dump_*.tar.gz", schema)
+// FindLatestDump finds the latest PostgreSQL dump file for a given schema in dir.
+func FindLatestDump(dir, schema string) (string, error) {
+	pattern := filepath.Join(dir, fmt.Sprintf("%s_dump_*.tar.gz", schema))
 	matches, err := filepath.Glob(pattern)
 	if err != nil {
 		return "", err
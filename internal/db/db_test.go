@@ -63,7 +63,7 @@ func TestFindLatestDump(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Test case 1: Dumps exist, find latest
-	foundDump, err := FindLatestDump(schema)
+	foundDump, err := FindLatestDump(".", schema)
 	assert.NoError(t, err)
 	assert.Equal(t, latestDumpName, foundDump)
 
@@ -72,14 +72,14 @@ func TestFindLatestDump(t *testing.T) {
 	os.Remove(middleDumpName)
 	os.Remove(latestDumpName)
 
-	_, err = FindLatestDump(schema)
+	_, err = FindLatestDump(".", schema)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no dump files found")
 
 	// Test case 3: Only one dump exists
 	_, err = os.Create(oldDumpName)
 	assert.NoError(t, err)
-	foundDump, err = FindLatestDump(schema)
+	foundDump, err = FindLatestDump(".", schema)
 	assert.NoError(t, err)
 	assert.Equal(t, oldDumpName, foundDump)
 }
@@ -0,0 +1,304 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// Labels set by docker compose on the resources of a project.
+const (
+	composeProjectLabel    = "com.docker.compose.project"
+	composeServiceLabel    = "com.docker.compose.service"
+	composeWorkingDirLabel = "com.docker.compose.project.working_dir"
+)
+
+// ComposeService is the state of one container of a compose project.
+type ComposeService struct {
+	Service   string
+	Container string
+	State     string // running, exited, ...
+	Health    string // healthy, unhealthy, starting or "" without healthcheck
+	Image     string
+}
+
+// composeContainers returns the containers compose created for the project
+// in dir, matched by the working directory label compose records.
+func composeContainers(ctx context.Context, dir string) ([]container.Summary, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	containers, err := cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", composeWorkingDirLabel+"="+dir)),
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].Labels[composeServiceLabel] < containers[j].Labels[composeServiceLabel]
+	})
+	return containers, nil
+}
+
+// ComposeServices reports the state and health of each container of the
+// compose project in dir.
+func ComposeServices(ctx context.Context, dir string) ([]ComposeService, error) {
+	containers, err := composeContainers(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	services := make([]ComposeService, 0, len(containers))
+	for _, c := range containers {
+		s := ComposeService{
+			Service:   c.Labels[composeServiceLabel],
+			Container: containerName(c.Names),
+			State:     c.State,
+			Image:     c.Image,
+		}
+		if info, err := inspectContainer(ctx, c.ID); err == nil && info.State != nil && info.State.Health != nil {
+			s.Health = info.State.Health.Status
+		}
+		services = append(services, s)
+	}
+	return services, nil
+}
+
+func inspectContainer(ctx context.Context, id string) (container.InspectResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	return cli.ContainerInspect(ctx, id)
+}
+
+// composeProjectName returns the name docker compose gives the project in
+// dir unless the compose file sets one: COMPOSE_PROJECT_NAME or the
+// directory name, lowercased and reduced to the characters compose allows.
+func composeProjectName(dir string) string {
+	name := os.Getenv("COMPOSE_PROJECT_NAME")
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimLeft(b.String(), "_-")
+}
+
+// composeProjectFilter matches the networks and volumes of a compose project.
+func composeProjectFilter(project string) filters.Args {
+	return filters.NewArgs(filters.Arg("label", composeProjectLabel+"="+project))
+}
+
+// ComposeProjectExists reports whether the compose project in dir has any
+// containers, networks or volumes left.
+func ComposeProjectExists(ctx context.Context, dir string) bool {
+	if containers, err := composeContainers(ctx, dir); err == nil && len(containers) > 0 {
+		return true
+	}
+	project := composeProjectName(dir)
+	if project == "" {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	if networks, err := cli.NetworkList(ctx, network.ListOptions{Filters: composeProjectFilter(project)}); err == nil && len(networks) > 0 {
+		return true
+	}
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: composeProjectFilter(project)})
+	return err == nil && len(volumes.Volumes) > 0
+}
+
+// ComposeDown stops and removes the containers and networks of the compose
+// project in dir, like 'docker compose down'. Named volumes of the project
+// are only removed when removeVolumes is set. Networks and volumes are found
+// by project name as well, so they are removed after the containers are gone.
+func ComposeDown(ctx context.Context, dir string, removeVolumes bool) error {
+	containers, err := composeContainers(ctx, dir)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	projects := map[string]bool{}
+	if project := composeProjectName(dir); project != "" {
+		projects[project] = true
+	}
+	for _, c := range containers {
+		projects[c.Labels[composeProjectLabel]] = true
+		name := containerName(c.Names)
+		fmt.Printf("Stopping %s...\n", name)
+		stopCtx, cancel := context.WithTimeout(ctx, stopTimeout)
+		err := cli.ContainerStop(stopCtx, c.ID, container.StopOptions{})
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", name, apiError(err)))
+		}
+		apiCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+		err = cli.ContainerRemove(apiCtx, c.ID, container.RemoveOptions{})
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("remove %s: %w", name, apiError(err)))
+		}
+	}
+
+	for project := range projects {
+		errs = append(errs, composeProjectDown(ctx, project, removeVolumes)...)
+	}
+	return errors.Join(errs...)
+}
+
+// composeProjectDown removes the networks, and the volumes when asked, of a
+// compose project; together the calls are bounded by apiTimeout.
+func composeProjectDown(ctx context.Context, project string, removeVolumes bool) []error {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	var errs []error
+	byProject := composeProjectFilter(project)
+	networks, err := cli.NetworkList(ctx, network.ListOptions{Filters: byProject})
+	if err != nil {
+		errs = append(errs, apiError(err))
+	}
+	for _, n := range networks {
+		if err := cli.NetworkRemove(ctx, n.ID); err != nil {
+			errs = append(errs, fmt.Errorf("remove network %s: %w", n.Name, apiError(err)))
+		}
+	}
+	if !removeVolumes {
+		return errs
+	}
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: byProject})
+	if err != nil {
+		return append(errs, apiError(err))
+	}
+	for _, v := range volumes.Volumes {
+		if err := cli.VolumeRemove(ctx, v.Name, false); err != nil {
+			errs = append(errs, fmt.Errorf("remove volume %s: %w", v.Name, apiError(err)))
+		}
+	}
+	return errs
+}
+
+// ComposeLogs writes the logs of the given services (all when empty) of the
+// compose project in dir to w, each line prefixed with its service. tail is
+// the number of lines per service ("all" for everything).
+func ComposeLogs(ctx context.Context, dir string, services []string, tail string, follow bool, w io.Writer) error {
+	containers, err := composeContainers(ctx, dir)
+	if err != nil {
+		return err
+	}
+	wanted := map[string]bool{}
+	for _, s := range services {
+		wanted[s] = true
+	}
+	var selected []container.Summary
+	for _, c := range containers {
+		if len(wanted) == 0 || wanted[c.Labels[composeServiceLabel]] {
+			selected = append(selected, c)
+			delete(wanted, c.Labels[composeServiceLabel])
+		}
+	}
+	if len(wanted) > 0 {
+		missing := make([]string, 0, len(wanted))
+		for s := range wanted {
+			missing = append(missing, s)
+		}
+		sort.Strings(missing)
+		return fmt.Errorf("no such compose service: %s", strings.Join(missing, ", "))
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no compose containers found for %s; is the environment started?", dir)
+	}
+
+	width := 0
+	for _, c := range selected {
+		width = max(width, len(c.Labels[composeServiceLabel]))
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(selected))
+	for i, c := range selected {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reader, err := cli.ContainerLogs(ctx, c.ID, container.LogsOptions{
+				ShowStdout: true,
+				ShowStderr: true,
+				Follow:     follow,
+				Tail:       tail,
+			})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer reader.Close()
+			out := &prefixWriter{w: w, mu: &mu, prefix: fmt.Sprintf("\x1b[36m%-*s |\x1b[0m ", width, c.Labels[composeServiceLabel])}
+			if _, err := stdcopy.StdCopy(out, out, reader); err != nil && ctx.Err() == nil {
+				errs[i] = err
+			}
+			out.flush()
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// prefixWriter writes complete lines with a prefix; writers sharing mu do
+// not interleave within a line.
+type prefixWriter struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    bytes.Buffer
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf.Write(b)
+	for {
+		line, err := p.buf.ReadBytes('\n')
+		if err != nil {
+			// keep the incomplete line for the next write
+			p.buf.Write(line)
+			return len(b), nil
+		}
+		p.mu.Lock()
+		_, werr := fmt.Fprintf(p.w, "%s%s", p.prefix, line)
+		p.mu.Unlock()
+		if werr != nil {
+			return 0, werr
+		}
+	}
+}
+
+func (p *prefixWriter) flush() {
+	if p.buf.Len() == 0 {
+		return
+	}
+	p.mu.Lock()
+	fmt.Fprintf(p.w, "%s%s\n", p.prefix, p.buf.String())
+	p.mu.Unlock()
+	p.buf.Reset()
+}
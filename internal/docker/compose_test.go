@@ -0,0 +1,30 @@
+package docker
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixWriterPrefixesCompleteLines(t *testing.T) {
+	var out bytes.Buffer
+	w := &prefixWriter{w: &out, mu: &sync.Mutex{}, prefix: "db | "}
+
+	_, _ = w.Write([]byte("first\nsec"))
+	_, _ = w.Write([]byte("ond\nthird"))
+	assert.Equal(t, "db | first\ndb | second\n", out.String())
+
+	w.flush()
+	assert.Equal(t, "db | first\ndb | second\ndb | third\n", out.String())
+}
+
+func TestComposeProjectName(t *testing.T) {
+	t.Setenv("COMPOSE_PROJECT_NAME", "")
+	assert.Equal(t, "compose-develop", composeProjectName("/work/app/docker/compose-develop"))
+	assert.Equal(t, "my_app2", composeProjectName("/work/_My_App.2"))
+
+	t.Setenv("COMPOSE_PROJECT_NAME", "Shop")
+	assert.Equal(t, "shop", composeProjectName("/work/app/docker/compose-develop"))
+}
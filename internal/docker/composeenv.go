@@ -0,0 +1,106 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/utils"
+)
+
+// ComposeEnv is a docker compose environment of the project: a directory
+// below <model>/docker or <application>/docker holding a compose file.
+type ComposeEnv struct {
+	Name  string
+	Dir   string
+	Files []string // the compose file followed by its override files
+}
+
+// composeFileNames in the order docker compose itself prefers them.
+var composeFileNames = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// composeRoots returns the directories holding compose environments. Both
+// the model layout (<model>/docker) and the application layout
+// (<application>/docker) are supported; the first one wins on name clashes.
+func composeRoots(cfg *config.Config) []string {
+	roots := []string{filepath.Join(cfg.ModelDir, "docker")}
+	if app := filepath.Join(cfg.AppDir, "docker"); app != roots[0] {
+		roots = append(roots, app)
+	}
+	return roots
+}
+
+// ComposeEnvs lists the compose environments of the project by name.
+func ComposeEnvs(cfg *config.Config) []ComposeEnv {
+	var envs []ComposeEnv
+	seen := map[string]bool{}
+	for _, root := range composeRoots(cfg) {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if !e.IsDir() || seen[e.Name()] {
+				continue
+			}
+			if env, ok := loadComposeEnv(e.Name(), filepath.Join(root, e.Name())); ok {
+				envs = append(envs, env)
+				seen[e.Name()] = true
+			}
+		}
+	}
+	sort.Slice(envs, func(i, j int) bool { return envs[i].Name < envs[j].Name })
+	return envs
+}
+
+func loadComposeEnv(name, dir string) (ComposeEnv, bool) {
+	for _, f := range composeFileNames {
+		main := filepath.Join(dir, f)
+		if !utils.FileExists(main) {
+			continue
+		}
+		env := ComposeEnv{Name: name, Dir: dir, Files: []string{main}}
+		ext := filepath.Ext(f)
+		override := filepath.Join(dir, strings.TrimSuffix(f, ext)+".override"+ext)
+		if utils.FileExists(override) {
+			env.Files = append(env.Files, override)
+		}
+		return env, true
+	}
+	return ComposeEnv{}, false
+}
+
+// ResolveComposeEnv returns the environment selected by compose_env, or an
+// error listing the available environments.
+func ResolveComposeEnv(cfg *config.Config) (ComposeEnv, error) {
+	envs := ComposeEnvs(cfg)
+	names := make([]string, 0, len(envs))
+	for _, env := range envs {
+		if env.Name == cfg.ComposeEnv {
+			return env, nil
+		}
+		names = append(names, env.Name)
+	}
+
+	available := "none found in " + strings.Join(composeRoots(cfg), " or ")
+	if len(names) > 0 {
+		available = strings.Join(names, ", ")
+	}
+	if cfg.ComposeEnv == "" {
+		return ComposeEnv{}, fmt.Errorf("compose_env is not set (available: %s); set it with 'judo config set compose_env <env>'", available)
+	}
+	return ComposeEnv{}, fmt.Errorf("compose environment %q not found (available: %s)", cfg.ComposeEnv, available)
+}
@@ -0,0 +1,64 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"judo-cli-module/internal/config"
+)
+
+func writeComposeFile(t *testing.T, path string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("services: {}\n"), 0o644))
+}
+
+func TestComposeEnvsCoversBothLayouts(t *testing.T) {
+	root := t.TempDir()
+	cfg := &config.Config{ModelDir: root, AppDir: filepath.Join(root, "application")}
+
+	writeComposeFile(t, filepath.Join(root, "docker", "compose-develop", "docker-compose.yml"))
+	writeComposeFile(t, filepath.Join(root, "application", "docker", "compose-https", "compose.yaml"))
+	writeComposeFile(t, filepath.Join(root, "application", "docker", "compose-develop", "compose.yaml"))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "docker", "empty"), 0o755))
+
+	envs := ComposeEnvs(cfg)
+	require.Len(t, envs, 2)
+	assert.Equal(t, "compose-develop", envs[0].Name)
+	assert.Equal(t, filepath.Join(root, "docker", "compose-develop"), envs[0].Dir)
+	assert.Equal(t, "compose-https", envs[1].Name)
+	assert.Equal(t, filepath.Join(root, "application", "docker", "compose-https"), envs[1].Dir)
+}
+
+func TestComposeEnvPrefersComposeYamlAndAddsOverride(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "docker", "dev")
+	writeComposeFile(t, filepath.Join(dir, "docker-compose.yml"))
+	writeComposeFile(t, filepath.Join(dir, "compose.yaml"))
+	writeComposeFile(t, filepath.Join(dir, "compose.override.yaml"))
+
+	env, ok := loadComposeEnv("dev", dir)
+	require.True(t, ok)
+	assert.Equal(t, []string{filepath.Join(dir, "compose.yaml"), filepath.Join(dir, "compose.override.yaml")}, env.Files)
+}
+
+func TestResolveComposeEnvListsAvailableEnvironments(t *testing.T) {
+	root := t.TempDir()
+	writeComposeFile(t, filepath.Join(root, "docker", "a", "compose.yaml"))
+	writeComposeFile(t, filepath.Join(root, "docker", "b", "compose.yml"))
+	cfg := &config.Config{ModelDir: root, AppDir: root, ComposeEnv: "missing"}
+
+	_, err := ResolveComposeEnv(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"missing" not found`)
+	assert.Contains(t, err.Error(), "a, b")
+
+	cfg.ComposeEnv = "b"
+	env, err := ResolveComposeEnv(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "docker", "b"), env.Dir)
+}
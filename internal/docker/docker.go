@@ -1,25 +1,25 @@
 package docker
 
 import (
+	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"io"
-	"io/fs"
 	"log"
+	"maps"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"judo-cli-module/internal/config"
-	"judo-cli-module/internal/utils"
+	"judo-cli-module/internal/readiness"
+	"judo-cli-module/internal/rollback"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 )
 
@@ -30,8 +30,10 @@ func init() {
 	//cli, err = client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	cli, err = newDockerClient()
 	if err != nil {
-		// Don't fatal here - just log and continue
+		// Don't fatal here - just log and continue. The fallback client
+		// fails every call with an error wrapping ErrDaemonUnreachable.
 		log.Printf("Warning: Failed to create Docker client: %v", err)
+		cli, _ = client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	}
 }
 
@@ -47,31 +49,19 @@ func CloseDockerClient() {
 }
 
 func newDockerClient() (*client.Client, error) {
-	// 1) Respect env (DOCKER_HOST etc.)
-	if cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation()); err == nil {
-		if pingOK(cli) {
-			return cli, nil
-		}
-	}
-
-	home, _ := os.UserHomeDir()
-	candidates := []string{
-		"unix:///var/run/docker.sock",                     // Linux / some Desktop setups
-		"unix://" + home + "/.docker/run/docker.sock",     // Docker Desktop macOS
-		"unix://" + home + "/.colima/default/docker.sock", // Colima
-	}
-
-	for _, h := range candidates {
-		cli, err := client.NewClientWithOpts(client.WithHost(h), client.WithAPIVersionNegotiation())
+	for _, e := range engineCandidates(os.Getenv) {
+		cli, err := client.NewClientWithOpts(client.FromEnv, client.WithHost(e.Host), client.WithAPIVersionNegotiation())
 		if err != nil {
 			continue
 		}
 		if pingOK(cli) {
+			e.Podman = serverIsPodman(cli)
+			engine, engineFound = e, true
 			return cli, nil
 		}
 		_ = cli.Close()
 	}
-	return nil, errors.New("could not reach Docker daemon; set DOCKER_HOST or start Docker Desktop/Colima")
+	return nil, fmt.Errorf("%w; set DOCKER_HOST, select a docker context or start Docker/Podman", ErrDaemonUnreachable)
 }
 
 func pingOK(cli *client.Client) bool {
@@ -81,98 +71,105 @@ func pingOK(cli *client.Client) bool {
 	return err == nil
 }
 
-func pullImage(imageName string) {
-	reader, err := cli.ImagePull(context.Background(), imageName, image.PullOptions{})
-	if err != nil {
-		log.Fatalf("Failed to pull image %s: %v", imageName, err)
-	}
-	defer reader.Close()
-	io.Copy(os.Stdout, reader)
+func serverIsPodman(cli *client.Client) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	v, err := cli.ServerVersion(ctx)
+	return err == nil && isPodman(v)
 }
 
+// Timeouts of single Docker API calls, so a hung daemon cannot block the
+// CLI forever. Pulls, log streams and readiness waits are long-running and
+// only bounded by the caller's context.
+const (
+	apiTimeout  = 30 * time.Second
+	stopTimeout = 60 * time.Second
+)
+
 // IsDockerRunning checks if the Docker daemon is responsive.
-func IsDockerRunning() bool {
-	_, err := cli.Ping(context.Background())
+func IsDockerRunning(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err := cli.Ping(ctx)
 	return err == nil
 }
 
-func GetComposeEnvs(cfg *config.Config) []string {
-	root := filepath.Join(cfg.AppDir, "docker")
-	var envs []string
-	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
-		if err != nil || d.IsDir() {
-			return nil
-		}
-		if filepath.Base(path) == "docker-compose.yml" {
-			envs = append(envs, filepath.Base(filepath.Dir(path)))
-		}
-		return nil
-	})
-	return envs
-}
-
-func StopCompose(cfg *config.Config, env string) error {
-	composeFile := filepath.Join(cfg.AppDir, "docker", env, "docker-compose.yml")
-	cmd := utils.ExecuteCommand("docker", "compose", "-f", composeFile, "down", "--volumes")
-	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
-	return cmd.Run()
-}
-
-func RemoveDockerInstance(name string) error {
+func RemoveDockerInstance(ctx context.Context, name string) error {
 	if name == "" {
 		return nil
 	}
-	return cli.ContainerRemove(context.Background(), name, container.RemoveOptions{Force: true})
+	ctx, cancel := context.WithTimeout(ctx, stopTimeout)
+	defer cancel()
+	return cli.ContainerRemove(ctx, name, container.RemoveOptions{Force: true})
 }
-func CreateDockerNetwork(name string) {
-	networks, err := cli.NetworkList(context.Background(), network.ListOptions{})
+
+// CreateDockerNetwork creates the network unless it exists. A new network is
+// recorded for rollback.
+func CreateDockerNetwork(ctx context.Context, name string) error {
+	apiCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	networks, err := cli.NetworkList(apiCtx, network.ListOptions{})
 	if err != nil {
-		log.Fatalf("Failed to list Docker networks: %v", err)
+		return fmt.Errorf("failed to list Docker networks: %w", apiError(err))
 	}
 	for _, network := range networks {
 		if network.Name == name {
-			return
+			return nil
 		}
 	}
-	_, err = cli.NetworkCreate(context.Background(), name, network.CreateOptions{})
+	_, err = cli.NetworkCreate(apiCtx, name, network.CreateOptions{
+		Labels: Labels(config.GetConfig(), "network"),
+	})
 	if err != nil {
-		log.Fatalf("Failed to create Docker network: %v", err)
+		return fmt.Errorf("failed to create Docker network: %w", apiError(err))
+	}
+	rollback.Record(ctx, "network "+name, func(ctx context.Context) error { return RemoveDockerNetwork(ctx, name) })
+	return nil
+}
+
+func DockerNetworkExists(ctx context.Context, name string) bool {
+	if name == "" {
+		return false
 	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	_, err := cli.NetworkInspect(ctx, name, network.InspectOptions{})
+	return err == nil
 }
 
-func RemoveDockerNetwork(name string) error {
+func RemoveDockerNetwork(ctx context.Context, name string) error {
 	if name == "" {
 		return nil
 	}
-	return cli.NetworkRemove(context.Background(), name)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	return cli.NetworkRemove(ctx, name)
 }
 
-func RemoveDockerVolume(name string) error {
+func RemoveDockerVolume(ctx context.Context, name string) error {
 	if name == "" {
 		return nil
 	}
-	return cli.VolumeRemove(context.Background(), name, true)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	return cli.VolumeRemove(ctx, name, true)
 }
 
-func DockerVolumeExists(name string) bool {
+func DockerVolumeExists(ctx context.Context, name string) bool {
 	if name == "" {
 		return false
 	}
-	_, err := cli.VolumeInspect(context.Background(), name)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	_, err := cli.VolumeInspect(ctx, name)
 	return err == nil
 }
 
 // Docker stop helper (no-op if not running)
-func DockerInstanceRunning(name string) bool {
-	// Create a fresh Docker client to avoid stale connections
-	client, err := newDockerClient()
-	if err != nil {
-		log.Printf("Failed to create Docker client: %v", err)
-		return false
-	}
-	defer client.Close()
-
-	containers, err := client.ContainerList(context.Background(), container.ListOptions{})
+func DockerInstanceRunning(ctx context.Context, name string) bool {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	containers, err := cli.ContainerList(ctx, container.ListOptions{})
 	if err != nil {
 		log.Printf("Failed to list Docker containers: %v", err)
 		return false
@@ -188,69 +185,144 @@ func DockerInstanceRunning(name string) bool {
 	return false
 }
 
-func StopDockerInstance(name string) error {
-	if DockerInstanceRunning(name) {
-		return cli.ContainerStop(context.Background(), name, container.StopOptions{})
+func StopDockerInstance(ctx context.Context, name string) error {
+	if DockerInstanceRunning(ctx, name) {
+		ctx, cancel := context.WithTimeout(ctx, stopTimeout)
+		defer cancel()
+		return cli.ContainerStop(ctx, name, container.StopOptions{})
 	}
 	return nil
 }
 
-func ContainerExists(name string) bool {
-	containers, err := cli.ContainerList(context.Background(), container.ListOptions{All: true})
+func ContainerExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true})
 	if err != nil {
-		log.Fatalf("Failed to list Docker containers: %v", err)
+		return false, fmt.Errorf("failed to list Docker containers: %w", apiError(err))
 	}
 	for _, c := range containers {
 		for _, n := range c.Names {
 			if strings.TrimPrefix(n, "/") == name {
-				return true
+				return true, nil
 			}
 		}
 	}
-	return false
+	return false, nil
 }
 
-func StartContainer(name string) {
-	if err := cli.ContainerStart(context.Background(), name, container.StartOptions{}); err != nil {
-		log.Fatalf("Failed to start container %s: %v", name, err)
+// ContainerImage returns the image reference a container was created from.
+func ContainerImage(ctx context.Context, name string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	info, err := cli.ContainerInspect(ctx, name)
+	if err != nil {
+		return "", err
 	}
+	return info.Config.Image, nil
 }
 
-func StartCompose() {
-	cfg := config.GetConfig()
-	fmt.Println("Starting Docker compose environment...")
-	cmd := utils.ExecuteCommand("docker", "compose", "-f", fmt.Sprintf("%s/docker/%s/docker-compose.yml", cfg.ModelDir, cfg.ComposeEnv), "up")
+func StartContainer(ctx context.Context, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	if err := cli.ContainerStart(ctx, name, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container %s: %w", name, apiError(err))
+	}
+	return nil
+}
+
+// StartCompose runs the compose environment in the foreground. Cancelling
+// ctx interrupts docker compose, which then stops the services it started.
+func StartCompose(ctx context.Context, env ComposeEnv) error {
+	fmt.Printf("Starting Docker compose environment %s...\n", env.Name)
+	var args []string
+	for _, f := range env.Files {
+		args = append(args, "-f", f)
+	}
+	args = append(args, "up")
+	if config.Options.Offline {
+		args = append(args, "--pull", "never")
+	}
+	cmd := composeCommand(ctx, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	utils.CheckError(cmd.Run())
+	return cmd.Run()
 }
 
-func StartPostgres() {
+// StartPostgres creates or starts the PostgreSQL container and waits until
+// it accepts queries. Resources it creates are recorded for rollback.
+func StartPostgres(ctx context.Context) error {
 	cfg := config.GetConfig()
 	fmt.Println("Starting PostgreSQL...")
-	name := "postgres-" + cfg.SchemaName
-	image := "postgres:16.2"
-
-	if !ContainerExists(name) {
-		pullImage(image)
-		CreateDockerNetwork(cfg.AppName)
-		resp, err := cli.ContainerCreate(context.Background(), &container.Config{
-			Image: image,
+	names := cfg.Names()
+	name := names.PostgresContainer
+	image := cfg.PostgresImageRef()
+	settings := cfg.PostgresSettings()
+
+	// an existing database keeps the password it was initialized with
+	initialized := DockerVolumeExists(ctx, names.PostgresDBVolume)
+	if !initialized {
+		// legacy volumes are only looked for when the database volume is
+		// about to be created, so adoption runs once and not on every start
+		if err := AdoptLegacyVolumes(ctx, cfg, os.Stdout); err != nil {
+			return err
+		}
+		initialized = DockerVolumeExists(ctx, names.PostgresDBVolume)
+	}
+	if initialized {
+		if err := cfg.KeepLegacyDBPassword(); err != nil {
+			return err
+		}
+	}
+	creds, err := cfg.Credentials()
+	if err != nil {
+		return fmt.Errorf("failed to resolve database credentials: %w", err)
+	}
+	exists, err := ContainerExists(ctx, name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err := reconcileContainer(ctx, name, image, cfg.PostgresPort, settings, nil); err != nil {
+			return err
+		}
+	}
+	if exists, err = ContainerExists(ctx, name); err != nil {
+		return err
+	}
+	if !exists {
+		if err := EnsureImage(ctx, image); err != nil {
+			return err
+		}
+		if err := CreateDockerNetwork(ctx, names.Network); err != nil {
+			return err
+		}
+		for _, v := range names.PostgresVolumes() {
+			if err := ensureVolume(ctx, v, Labels(cfg, "postgres-data")); err != nil {
+				return fmt.Errorf("failed to create volume %s: %w", v, err)
+			}
+		}
+		cc := &container.Config{
+			Image:  image,
+			Labels: Labels(cfg, "postgres"),
 			Env: []string{
 				"PGDATA=/var/lib/postgresql/pgdata",
-				fmt.Sprintf("POSTGRES_USER=%s", cfg.SchemaName),
-				fmt.Sprintf("POSTGRES_PASSWORD=%s", cfg.SchemaName),
+				"POSTGRES_USER=" + creds.DBUser,
+				"POSTGRES_PASSWORD=" + creds.DBPassword,
+				"POSTGRES_DB=" + cfg.SchemaName,
 			},
+			Healthcheck:  postgresHealthcheck(),
 			AttachStdin:  false,
 			AttachStdout: false,
 			AttachStderr: false,
 			Tty:          false,
-		}, &container.HostConfig{
+		}
+		hc := &container.HostConfig{
 			Binds: []string{
-				fmt.Sprintf("%s_postgresql_db:/var/lib/postgresql/pgdata", cfg.SchemaName),
-				fmt.Sprintf("%s_postgresql_data:/var/lib/postgresql/data", cfg.SchemaName),
+				names.PostgresDBVolume + ":/var/lib/postgresql/pgdata",
+				names.PostgresDataVolume + ":/var/lib/postgresql/data",
 			},
-			NetworkMode: container.NetworkMode(cfg.AppName),
+			NetworkMode: container.NetworkMode(names.Network),
 			PortBindings: nat.PortMap{
 				"5432/tcp": []nat.PortBinding{
 					{
@@ -262,60 +334,168 @@ func StartPostgres() {
 			RestartPolicy: container.RestartPolicy{
 				Name: "unless-stopped",
 			},
-		}, &network.NetworkingConfig{}, nil, name)
-		if err != nil {
-			log.Fatalf("Failed to create PostgreSQL container: %v", err)
 		}
-		if err := cli.ContainerStart(context.Background(), resp.ID, container.StartOptions{
-			// Ensure the container starts detached
-		}); err != nil {
-			log.Fatalf("Failed to start PostgreSQL container: %v", err)
+		if len(settings.Args) > 0 {
+			// Extra arguments replace the image CMD, so it has to be repeated
+			cc.Cmd = []string{"postgres"}
+		}
+		if err := applySettings(cc, hc, settings, cfg.Root()); err != nil {
+			return fmt.Errorf("invalid PostgreSQL container settings: %w", err)
+		}
+		if err := createContainer(ctx, name, cc, hc); err != nil {
+			return fmt.Errorf("failed to create PostgreSQL container: %w", err)
+		}
+	}
+	if err := StartContainer(ctx, name); err != nil {
+		return err
+	}
+
+	timeout := time.Duration(cfg.PostgresTimeout) * time.Second
+	if err := WaitForContainer(ctx, "PostgreSQL", name, timeout,
+		ExecProbe(name, "pg_isready", "-h", "127.0.0.1", "-U", creds.DBUser, "-d", cfg.SchemaName)); err != nil {
+		return err
+	}
+	if initialized {
+		// POSTGRES_PASSWORD only applies to a new data directory
+		if err := setPostgresPassword(ctx, name, cfg.SchemaName, creds); err != nil {
+			return fmt.Errorf("failed to set the database password: %w", err)
+		}
+	}
+	return nil
+}
+
+// setPostgresPassword sets the password of the database user to the
+// configured one. psql connects through the unix socket, which the image
+// trusts, and reads the statement from stdin so the password is not on a
+// command line.
+func setPostgresPassword(ctx context.Context, name, schema string, creds config.Credentials) error {
+	sql := fmt.Sprintf("SET log_statement = 'none';\nALTER USER %s PASSWORD %s;\n",
+		`"`+strings.ReplaceAll(creds.DBUser, `"`, `""`)+`"`,
+		"'"+strings.ReplaceAll(creds.DBPassword, "'", "''")+"'")
+	out, err := runPSQL(ctx, name, schema, creds.DBUser, sql)
+	if err == nil {
+		return nil
+	}
+	if !strings.Contains(out, fmt.Sprintf("role %q does not exist", creds.DBUser)) {
+		return err
+	}
+	// db_user changed after the database was initialized; earlier versions
+	// used schema_name as user
+	if creds.DBUser != schema {
+		if _, legacyErr := runPSQL(ctx, name, schema, schema, "SELECT 1;\n"); legacyErr == nil {
+			return fmt.Errorf("database role %q does not exist, the database was initialized with role %q; set db_user to %s or create the role", creds.DBUser, schema, schema)
 		}
-	} else {
-		StartContainer(name)
 	}
-	utils.WaitForPort("localhost", cfg.PostgresPort, 30*utils.TimeSecond)
+	return fmt.Errorf("database role %q does not exist in the existing database; set db_user to the role it was initialized with, or remove it with 'judo clean --only postgres'", creds.DBUser)
+}
+
+// runPSQL runs sql with psql as user in the container and returns its
+// output.
+func runPSQL(ctx context.Context, name, schema, user, sql string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	exec, err := cli.ContainerExecCreate(ctx, name, container.ExecOptions{
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Env:          []string{"PGUSER=" + user},
+		Cmd:          []string{"psql", "-q", "-v", "ON_ERROR_STOP=1", "-d", schema},
+	})
+	if err != nil {
+		return "", apiError(err)
+	}
+	resp, err := cli.ContainerExecAttach(ctx, exec.ID, container.ExecStartOptions{})
+	if err != nil {
+		return "", apiError(err)
+	}
+	defer resp.Close()
+	if _, err := io.WriteString(resp.Conn, sql); err != nil {
+		return "", err
+	}
+	_ = resp.CloseWrite()
+	var out bytes.Buffer
+	_, _ = stdcopy.StdCopy(&out, &out, resp.Reader)
+
+	info, err := cli.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return "", apiError(err)
+	}
+	if info.ExitCode != 0 {
+		return out.String(), fmt.Errorf("psql: %s", strings.TrimSpace(out.String()))
+	}
+	return out.String(), nil
 }
 
-func StartKeycloak() {
+// StartKeycloak creates or starts the Keycloak container and waits until the
+// master realm is served. Resources it creates are recorded for rollback.
+func StartKeycloak(ctx context.Context) error {
 	cfg := config.GetConfig()
 	fmt.Println("Starting Keycloak...")
-	name := "keycloak-" + cfg.KeycloakName
-	image := "quay.io/keycloak/keycloak:23.0"
+	names := cfg.Names()
+	name := names.KeycloakContainer
+	image := cfg.KeycloakImageRef()
+	settings := cfg.KeycloakSettings()
 
-	if !ContainerExists(name) {
-		pullImage(image)
+	creds, err := cfg.Credentials()
+	if err != nil {
+		return fmt.Errorf("failed to resolve Keycloak credentials: %w", err)
+	}
+	// Keycloak keeps the database login it was created with
+	dbLabels := DBCredentialsLabels(cfg, creds)
+	exists, err := ContainerExists(ctx, name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err := reconcileContainer(ctx, name, image, cfg.KeycloakPort, settings, dbLabels); err != nil {
+			return err
+		}
+	}
+	if exists, err = ContainerExists(ctx, name); err != nil {
+		return err
+	}
+	if !exists {
+		if err := EnsureImage(ctx, image); err != nil {
+			return err
+		}
 		if cfg.DBType == "postgresql" {
-			CreateDockerNetwork(cfg.AppName)
+			if err := CreateDockerNetwork(ctx, names.Network); err != nil {
+				return err
+			}
 		}
 		env := []string{
-			"KEYCLOAK_ADMIN=admin",
-			"KEYCLOAK_ADMIN_PASSWORD=judo",
+			"KEYCLOAK_ADMIN=" + creds.KeycloakAdminUser,
+			"KEYCLOAK_ADMIN_PASSWORD=" + creds.KeycloakAdminPassword,
 		}
 		if cfg.DBType == "postgresql" {
 			env = append(env,
 				"KC_DB=postgres",
-				"KC_DB_URL_HOST=postgres-"+cfg.SchemaName,
+				"KC_DB_URL_HOST="+names.PostgresContainer,
 				"KC_DB_URL_DATABASE="+cfg.SchemaName,
-				"KC_DB_PASSWORD="+cfg.SchemaName,
-				"KC_DB_USERNAME="+cfg.SchemaName,
+				"KC_DB_PASSWORD="+creds.DBPassword,
+				"KC_DB_USERNAME="+creds.DBUser,
 				"KC_DB_SCHEMA=public",
 			)
 		}
-		resp, err := cli.ContainerCreate(context.Background(), &container.Config{
-			Image: image,
-			Env:   env,
+		labels := Labels(cfg, "keycloak")
+		maps.Copy(labels, dbLabels)
+		cc := &container.Config{
+			Image:  image,
+			Labels: labels,
+			Env:    env,
 			Cmd: []string{
 				"start-dev",
 				fmt.Sprintf("--http-port=%d", cfg.KeycloakPort),
 				"--http-relative-path", "/auth",
 			},
+			Healthcheck:  keycloakHealthcheck(cfg.KeycloakPort),
 			AttachStdin:  false,
 			AttachStdout: false,
 			AttachStderr: false,
 			Tty:          false,
-		}, &container.HostConfig{
-			NetworkMode: container.NetworkMode(cfg.AppName),
+		}
+		hc := &container.HostConfig{
+			NetworkMode: container.NetworkMode(names.Network),
 			PortBindings: nat.PortMap{
 				nat.Port(fmt.Sprintf("%d/tcp", cfg.KeycloakPort)): []nat.PortBinding{
 					{HostIP: "0.0.0.0", HostPort: fmt.Sprintf("%d", cfg.KeycloakPort)},
@@ -324,44 +504,54 @@ func StartKeycloak() {
 			RestartPolicy: container.RestartPolicy{
 				Name: "unless-stopped",
 			},
-		}, &network.NetworkingConfig{}, nil, name)
-		if err != nil {
-			log.Fatalf("Failed to create Keycloak container: %v", err)
 		}
-		if err := cli.ContainerStart(context.Background(), resp.ID, container.StartOptions{
-			// Ensure the container starts detached
-		}); err != nil {
-			log.Fatalf("Failed to start Keycloak container: %v", err)
+		if err := applySettings(cc, hc, settings, cfg.Root()); err != nil {
+			return fmt.Errorf("invalid Keycloak container settings: %w", err)
 		}
-		// Verify the container is running
-		time.Sleep(2 * time.Second) // Give it a moment to stabilize
-		if !DockerInstanceRunning(name) {
-			// If it's not running, get the logs to see why it failed.
-			reader, err := cli.ContainerLogs(context.Background(), name, container.LogsOptions{ShowStdout: true, ShowStderr: true})
-			if err != nil {
-				log.Fatalf("Failed to get Keycloak container logs: %v", err)
-			}
-			defer reader.Close()
-			logs, _ := io.ReadAll(reader)
-			log.Fatalf("Keycloak container failed to start. Logs:\n%s", string(logs))
+		if err := createContainer(ctx, name, cc, hc); err != nil {
+			return fmt.Errorf("failed to create Keycloak container: %w", err)
 		}
-	} else {
-		StartContainer(name)
 	}
-	utils.WaitForPort("localhost", cfg.KeycloakPort, 30*utils.TimeSecond)
+	if err := StartContainer(ctx, name); err != nil {
+		return err
+	}
+
+	timeout := time.Duration(cfg.KeycloakTimeout) * time.Second
+	base := fmt.Sprintf("http://localhost:%d/auth", cfg.KeycloakPort)
+	if err := WaitForContainer(ctx, "Keycloak", name, timeout, readiness.HTTP(base+"/realms/master", readiness.StatusOK)); err != nil {
+		return err
+	}
+	// KEYCLOAK_ADMIN_PASSWORD only applies to a new Keycloak database
+	if err := syncKeycloakAdminPassword(ctx, base, creds); err != nil {
+		return fmt.Errorf("failed to set the Keycloak admin password: %w", err)
+	}
+	return nil
+}
+
+// createContainer creates a container and records it for rollback.
+func createContainer(ctx context.Context, name string, cc *container.Config, hc *container.HostConfig) error {
+	apiCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	if _, err := cli.ContainerCreate(apiCtx, cc, hc, &network.NetworkingConfig{}, nil, name); err != nil {
+		return apiError(err)
+	}
+	rollback.Record(ctx, "container "+name, func(ctx context.Context) error { return RemoveDockerInstance(ctx, name) })
+	return nil
 }
 
 // IsPortUsedByKeycloak checks if a port is being used by the current Keycloak Docker container
-func IsPortUsedByKeycloak(port int) bool {
+func IsPortUsedByKeycloak(ctx context.Context, port int) bool {
 	cfg := config.GetConfig()
 	if cfg == nil {
 		return false
 	}
 
-	keycloakName := "keycloak-" + cfg.KeycloakName
+	keycloakName := cfg.Names().KeycloakContainer
 
 	// List all running containers
-	containers, err := cli.ContainerList(context.Background(), container.ListOptions{})
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	containers, err := cli.ContainerList(ctx, container.ListOptions{})
 	if err != nil {
 		return false
 	}
@@ -390,7 +580,7 @@ func isContainerUsingPort(container types.Container, port int) bool {
 }
 
 // IsPortUsedByPostgres checks if a port is being used by the current PostgreSQL Docker container
-func IsPortUsedByPostgres(port int) bool {
+func IsPortUsedByPostgres(ctx context.Context, port int) bool {
 	cfg := config.GetConfig()
 	if cfg == nil {
 		return false
@@ -400,10 +590,12 @@ func IsPortUsedByPostgres(port int) bool {
 		return false
 	}
 
-	postgresName := "postgres-" + cfg.SchemaName
+	postgresName := cfg.Names().PostgresContainer
 
 	// List all running containers
-	containers, err := cli.ContainerList(context.Background(), container.ListOptions{})
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	containers, err := cli.ContainerList(ctx, container.ListOptions{})
 	if err != nil {
 		return false
 	}
@@ -0,0 +1,174 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+)
+
+// Engine is a container engine endpoint the CLI can talk to.
+type Engine struct {
+	Name   string // human readable source, e.g. "rootless Docker" or "context colima"
+	Host   string // DOCKER_HOST style address; empty means the client defaults
+	Podman bool   // set from the version the engine reports once connected
+}
+
+// engine is the endpoint the global client was connected to; engineFound
+// tells whether discovery reached one, as cli is never nil.
+var (
+	engine      Engine
+	engineFound bool
+)
+
+// ActiveEngine returns the engine found during client discovery and whether
+// one was reachable at all.
+func ActiveEngine() (Engine, bool) {
+	return engine, engineFound
+}
+
+// engineCandidates lists the endpoints to probe in order: DOCKER_HOST, the
+// selected docker context, then the well-known sockets of Docker Engine,
+// rootless Docker, Docker Desktop, Colima and Podman, and last the platform
+// default host, which is the named pipe of Docker Desktop on Windows.
+func engineCandidates(getenv func(string) string) []Engine {
+	var engines []Engine
+	if h := getenv("DOCKER_HOST"); h != "" {
+		engines = append(engines, Engine{Name: "DOCKER_HOST", Host: h})
+	}
+	if name, host := currentDockerContext(getenv); host != "" {
+		engines = append(engines, Engine{Name: "context " + name, Host: host})
+	}
+
+	home := getenv("HOME")
+	runtimeDir := getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = "/run/user/" + strconv.Itoa(os.Getuid())
+	}
+	engines = append(engines,
+		Engine{Name: "Docker", Host: "unix:///var/run/docker.sock"},
+		Engine{Name: "rootless Docker", Host: "unix://" + filepath.Join(runtimeDir, "docker.sock")},
+		Engine{Name: "Docker Desktop", Host: "unix://" + filepath.Join(home, ".docker/run/docker.sock")},
+		Engine{Name: "Colima", Host: "unix://" + filepath.Join(home, ".colima/default/docker.sock")},
+		Engine{Name: "rootless Podman", Host: "unix://" + filepath.Join(runtimeDir, "podman/podman.sock")},
+		Engine{Name: "Podman", Host: "unix:///run/podman/podman.sock"},
+		Engine{Name: "default", Host: client.DefaultDockerHost},
+	)
+	return engines
+}
+
+// isPodman tells whether a server version comes from Podman, which lists
+// itself among the components, e.g. "Podman Engine". Socket paths do not
+// tell, as Podman may serve the Docker socket and vice versa.
+func isPodman(v types.Version) bool {
+	for _, c := range v.Components {
+		if strings.Contains(strings.ToLower(c.Name), "podman") {
+			return true
+		}
+	}
+	return false
+}
+
+// currentDockerContext returns the name and host of the docker context
+// selected by DOCKER_CONTEXT or by 'docker context use'. The built-in
+// "default" context is skipped, it is covered by the well-known sockets.
+func currentDockerContext(getenv func(string) string) (string, string) {
+	dir := getenv("DOCKER_CONFIG")
+	if dir == "" {
+		dir = filepath.Join(getenv("HOME"), ".docker")
+	}
+	name := getenv("DOCKER_CONTEXT")
+	if name == "" {
+		var cfg struct {
+			CurrentContext string `json:"currentContext"`
+		}
+		if data, err := os.ReadFile(filepath.Join(dir, "config.json")); err == nil {
+			_ = json.Unmarshal(data, &cfg)
+		}
+		name = cfg.CurrentContext
+	}
+	if name == "" || name == "default" {
+		return "", ""
+	}
+	return name, dockerContextHost(dir, name)
+}
+
+// dockerContextHost reads the docker endpoint of a context from the context
+// store, where metadata lives under meta/<sha256 of the name>/meta.json.
+func dockerContextHost(configDir, name string) string {
+	sum := sha256.Sum256([]byte(name))
+	data, err := os.ReadFile(filepath.Join(configDir, "contexts", "meta", hex.EncodeToString(sum[:]), "meta.json"))
+	if err != nil {
+		return ""
+	}
+	var meta struct {
+		Endpoints map[string]struct {
+			Host string `json:"Host"`
+		} `json:"Endpoints"`
+	}
+	if json.Unmarshal(data, &meta) != nil {
+		return ""
+	}
+	return meta.Endpoints["docker"].Host
+}
+
+// composeCommand returns a compose command bound to the active engine:
+// 'podman compose' for Podman when available, otherwise 'docker compose'
+// (or the standalone docker-compose) with DOCKER_HOST pointing at the engine.
+// Cancelling ctx interrupts the command like Ctrl-C would.
+func composeCommand(ctx context.Context, args ...string) *exec.Cmd {
+	name, base := composeBinary()
+	cmd := exec.CommandContext(ctx, name, append(base, args...)...)
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = stopTimeout
+	if engine.Host == "" {
+		return cmd
+	}
+	env := make([]string, 0, len(os.Environ())+1)
+	for _, kv := range os.Environ() {
+		// DOCKER_HOST wins over the context anyway; dropping DOCKER_CONTEXT
+		// avoids the CLI rejecting the combination.
+		if !strings.HasPrefix(kv, "DOCKER_HOST=") && !strings.HasPrefix(kv, "DOCKER_CONTEXT=") {
+			env = append(env, kv)
+		}
+	}
+	if engine.Podman && name == "podman" {
+		cmd.Env = append(env, "CONTAINER_HOST="+engine.Host)
+		return cmd
+	}
+	cmd.Env = append(env, "DOCKER_HOST="+engine.Host)
+	return cmd
+}
+
+func composeBinary() (string, []string) {
+	if engine.Podman {
+		if _, err := exec.LookPath("podman"); err == nil {
+			return "podman", []string{"compose"}
+		}
+	}
+	if _, err := exec.LookPath("docker"); err == nil {
+		return "docker", []string{"compose"}
+	}
+	if _, err := exec.LookPath("docker-compose"); err == nil {
+		return "docker-compose", nil
+	}
+	return "docker", []string{"compose"}
+}
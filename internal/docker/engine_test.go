@@ -0,0 +1,72 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envFunc(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func writeDockerContext(t *testing.T, configDir, name, host string) {
+	t.Helper()
+	sum := sha256.Sum256([]byte(name))
+	dir := filepath.Join(configDir, "contexts", "meta", hex.EncodeToString(sum[:]))
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	meta := `{"Name":"` + name + `","Endpoints":{"docker":{"Host":"` + host + `"}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "meta.json"), []byte(meta), 0o644))
+}
+
+func TestEngineCandidatesIncludeRootlessAndPodman(t *testing.T) {
+	engines := engineCandidates(envFunc(map[string]string{"HOME": t.TempDir(), "XDG_RUNTIME_DIR": "/run/user/1000"}))
+
+	hosts := map[string]Engine{}
+	for _, e := range engines {
+		hosts[e.Host] = e
+	}
+	assert.Contains(t, hosts, "unix:///run/user/1000/docker.sock")
+	assert.Contains(t, hosts, "unix:///run/user/1000/podman/podman.sock")
+}
+
+func TestEngineCandidatesEndWithPlatformDefault(t *testing.T) {
+	engines := engineCandidates(envFunc(map[string]string{"HOME": t.TempDir()}))
+	assert.Equal(t, Engine{Name: "default", Host: client.DefaultDockerHost}, engines[len(engines)-1])
+}
+
+func TestIsPodmanFromServerVersion(t *testing.T) {
+	assert.True(t, isPodman(types.Version{Components: []types.ComponentVersion{{Name: "Podman Engine", Version: "5.2.0"}}}))
+	assert.False(t, isPodman(types.Version{Components: []types.ComponentVersion{{Name: "Engine"}, {Name: "containerd"}}}))
+}
+
+func TestEngineCandidatesPreferDockerHostThenContext(t *testing.T) {
+	home := t.TempDir()
+	configDir := filepath.Join(home, ".docker")
+	writeDockerContext(t, configDir, "colima", "unix:///tmp/colima.sock")
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.json"), []byte(`{"currentContext":"colima"}`), 0o644))
+
+	engines := engineCandidates(envFunc(map[string]string{"HOME": home, "DOCKER_HOST": "tcp://remote:2375"}))
+	require.GreaterOrEqual(t, len(engines), 2)
+	assert.Equal(t, "tcp://remote:2375", engines[0].Host)
+	assert.Equal(t, Engine{Name: "context colima", Host: "unix:///tmp/colima.sock"}, engines[1])
+}
+
+func TestCurrentDockerContextFromEnvironment(t *testing.T) {
+	configDir := t.TempDir()
+	writeDockerContext(t, configDir, "rootless", "unix:///run/user/1000/docker.sock")
+
+	name, host := currentDockerContext(envFunc(map[string]string{"DOCKER_CONFIG": configDir, "DOCKER_CONTEXT": "rootless"}))
+	assert.Equal(t, "rootless", name)
+	assert.Equal(t, "unix:///run/user/1000/docker.sock", host)
+
+	_, host = currentDockerContext(envFunc(map[string]string{"DOCKER_CONFIG": configDir, "DOCKER_CONTEXT": "default"}))
+	assert.Empty(t, host)
+}
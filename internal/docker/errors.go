@@ -0,0 +1,51 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"judo-cli-module/internal/utils"
+
+	"github.com/docker/docker/client"
+)
+
+var (
+	// ErrDaemonUnreachable marks errors caused by no Docker or Podman daemon
+	// answering; retrying only helps once the daemon is started.
+	ErrDaemonUnreachable = errors.New("could not reach a Docker or Podman daemon")
+	// ErrImagePull marks an image that could not be pulled, or is missing
+	// locally while --offline is set.
+	ErrImagePull = errors.New("image not available")
+)
+
+// apiError classifies an error of the Docker API: connection failures wrap
+// ErrDaemonUnreachable and port binding conflicts wrap utils.ErrPortInUse.
+func apiError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case client.IsErrConnectionFailed(err):
+		return fmt.Errorf("%w: %w", ErrDaemonUnreachable, err)
+	case isPortConflict(err.Error()):
+		return fmt.Errorf("%w: %w", utils.ErrPortInUse, err)
+	}
+	return err
+}
+
+// isPortConflict recognizes the messages Docker and Podman return when a
+// published port is taken.
+func isPortConflict(msg string) bool {
+	return strings.Contains(msg, "port is already allocated") ||
+		strings.Contains(msg, "address already in use")
+}
@@ -0,0 +1,34 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"judo-cli-module/internal/utils"
+)
+
+func TestAPIErrorPortConflict(t *testing.T) {
+	for _, msg := range []string{
+		"driver failed programming external connectivity: Bind for 0.0.0.0:5432 failed: port is already allocated",
+		"rootlessport listen tcp 0.0.0.0:8080: bind: address already in use",
+	} {
+		err := apiError(errors.New(msg))
+		assert.ErrorIs(t, err, utils.ErrPortInUse, msg)
+		assert.NotErrorIs(t, err, ErrDaemonUnreachable, msg)
+	}
+}
+
+func TestAPIErrorPassesOtherErrors(t *testing.T) {
+	assert.NoError(t, apiError(nil))
+	other := errors.New("No such container: postgres-app")
+	assert.Same(t, other, apiError(other))
+}
+
+func TestPortInUseError(t *testing.T) {
+	err := fmt.Errorf("start: %w", &utils.PortInUseError{Service: "Keycloak", Port: 8080})
+	assert.ErrorIs(t, err, utils.ErrPortInUse)
+	assert.EqualError(t, err, "start: Keycloak port 8080 is already in use by another process")
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"judo-cli-module/internal/config"
+
+	"github.com/docker/docker/client"
+)
+
+// ProjectImage is a container image the project needs, with where the need
+// comes from: "postgres", "keycloak" or "compose:<env>/<service>".
+type ProjectImage struct {
+	Ref     string
+	Sources []string
+}
+
+// ProjectImages lists the images needed by the project: PostgreSQL (when
+// dbtype is postgresql), Keycloak and the images of every compose service.
+// Compose environments whose configuration cannot be read are reported in
+// the returned error, the other images are still listed.
+func ProjectImages(ctx context.Context, cfg *config.Config) ([]ProjectImage, error) {
+	byRef := map[string]*ProjectImage{}
+	var order []string
+	add := func(ref, source string) {
+		if img, ok := byRef[ref]; ok {
+			img.Sources = append(img.Sources, source)
+			return
+		}
+		byRef[ref] = &ProjectImage{Ref: ref, Sources: []string{source}}
+		order = append(order, ref)
+	}
+
+	if cfg.DBType == "postgresql" {
+		add(cfg.PostgresImageRef(), "postgres")
+	}
+	add(cfg.KeycloakImageRef(), "keycloak")
+
+	var errs []error
+	for _, env := range ComposeEnvs(cfg) {
+		services, err := composeServiceImages(ctx, env)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("compose environment %s: %w", env.Name, err))
+			continue
+		}
+		names := make([]string, 0, len(services))
+		for name := range services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			add(services[name], "compose:"+env.Name+"/"+name)
+		}
+	}
+
+	images := make([]ProjectImage, 0, len(order))
+	for _, ref := range order {
+		images = append(images, *byRef[ref])
+	}
+	return images, errors.Join(errs...)
+}
+
+// composeServiceImages returns the image of each service of env as resolved
+// by 'docker compose config'. Services that are only built locally have no
+// image and are skipped.
+func composeServiceImages(ctx context.Context, env ComposeEnv) (map[string]string, error) {
+	var args []string
+	for _, f := range env.Files {
+		args = append(args, "-f", f)
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	cmd := composeCommand(ctx, append(args, "config", "--format", "json")...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseComposeImages(stdout.Bytes())
+}
+
+func parseComposeImages(data []byte) (map[string]string, error) {
+	var project struct {
+		Services map[string]struct {
+			Image string `json:"image"`
+		} `json:"services"`
+	}
+	if err := json.Unmarshal(data, &project); err != nil {
+		return nil, err
+	}
+	images := map[string]string{}
+	for name, s := range project.Services {
+		if s.Image != "" {
+			images[name] = s.Image
+		}
+	}
+	return images, nil
+}
+
+// ImagePresent reports whether ref is available locally and its size.
+func ImagePresent(ctx context.Context, ref string) (bool, int64) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	info, err := cli.ImageInspect(ctx, ref)
+	if err != nil {
+		return false, 0
+	}
+	return true, info.Size
+}
+
+// SaveImages writes refs into a tar bundle at path, like 'docker save'.
+func SaveImages(ctx context.Context, refs []string, path string) error {
+	reader, err := cli.ImageSave(ctx, refs)
+	if err != nil {
+		return fmt.Errorf("failed to export images: %w", err)
+	}
+	defer reader.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, reader); err != nil {
+		f.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// LoadImages imports a tar bundle written by SaveImages (or 'docker save')
+// and prints the loaded images to w.
+func LoadImages(ctx context.Context, path string, w io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	resp, err := cli.ImageLoad(ctx, f, client.ImageLoadWithQuiet(true))
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var m pullMessage
+		if err := dec.Decode(&m); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if m.ErrorDetail.Message != "" {
+			return fmt.Errorf("failed to load %s: %s", path, m.ErrorDetail.Message)
+		}
+		if m.Error != "" {
+			return fmt.Errorf("failed to load %s: %s", path, m.Error)
+		}
+		if line := strings.TrimSpace(m.Stream); line != "" {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+}
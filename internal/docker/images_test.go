@@ -0,0 +1,40 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"judo-cli-module/internal/config"
+)
+
+func TestParseComposeImagesSkipsBuiltServices(t *testing.T) {
+	images, err := parseComposeImages([]byte(`{"name":"dev","services":{
+		"db":{"image":"postgres:16.2"},
+		"app":{"build":{"context":"."}},
+		"kc":{"image":"quay.io/keycloak/keycloak:23.0"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"db": "postgres:16.2", "kc": "quay.io/keycloak/keycloak:23.0"}, images)
+}
+
+func TestProjectImagesWithoutComposeEnvironments(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{ModelDir: dir, AppDir: dir, DBType: "postgresql",
+		PostgresImage: "postgres", PostgresVersion: "16.2",
+		KeycloakImage: "quay.io/keycloak/keycloak", KeycloakVersion: "23.0"}
+
+	images, err := ProjectImages(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []ProjectImage{
+		{Ref: "postgres:16.2", Sources: []string{"postgres"}},
+		{Ref: "quay.io/keycloak/keycloak:23.0", Sources: []string{"keycloak"}},
+	}, images)
+
+	cfg.DBType = "hsqldb"
+	images, err = ProjectImages(context.Background(), cfg)
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	assert.Equal(t, "quay.io/keycloak/keycloak:23.0", images[0].Ref)
+}
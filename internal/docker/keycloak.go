@@ -0,0 +1,133 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"judo-cli-module/internal/config"
+)
+
+// legacyKeycloakAdminPassword is the admin password Keycloak was created
+// with by earlier versions.
+const legacyKeycloakAdminPassword = "judo"
+
+var errInvalidLogin = errors.New("invalid user credentials")
+
+// syncKeycloakAdminPassword makes the configured admin password the one the
+// running Keycloak at baseURL (ending in /auth) accepts. KEYCLOAK_ADMIN_PASSWORD
+// only applies when Keycloak initializes its database, so a Keycloak set up by
+// an earlier version still has the legacy password; it is changed through the
+// admin REST API.
+func syncKeycloakAdminPassword(ctx context.Context, baseURL string, creds config.Credentials) error {
+	user := creds.KeycloakAdminUser
+	_, err := keycloakToken(ctx, baseURL, user, creds.KeycloakAdminPassword)
+	if !errors.Is(err, errInvalidLogin) {
+		return err
+	}
+	token, err := keycloakToken(ctx, baseURL, user, legacyKeycloakAdminPassword)
+	if errors.Is(err, errInvalidLogin) {
+		return fmt.Errorf("Keycloak rejects the configured keycloak_admin_password of %s; set it to the current admin password", user)
+	}
+	if err != nil {
+		return err
+	}
+
+	var users []struct {
+		ID string `json:"id"`
+	}
+	query := url.Values{"username": {user}, "exact": {"true"}}
+	if err := keycloakAdmin(ctx, http.MethodGet, baseURL+"/admin/realms/master/users?"+query.Encode(), token, nil, &users); err != nil {
+		return err
+	}
+	if len(users) != 1 {
+		return fmt.Errorf("Keycloak admin user %s not found", user)
+	}
+	reset := map[string]any{"type": "password", "value": creds.KeycloakAdminPassword, "temporary": false}
+	if err := keycloakAdmin(ctx, http.MethodPut, baseURL+"/admin/realms/master/users/"+users[0].ID+"/reset-password", token, reset, nil); err != nil {
+		return err
+	}
+	fmt.Printf("Changed the Keycloak admin password from the one of earlier versions to the configured one\n")
+	return nil
+}
+
+// keycloakToken logs in to the master realm with the admin-cli client.
+func keycloakToken(ctx context.Context, baseURL, user, password string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	form := url.Values{"grant_type": {"password"}, "client_id": {"admin-cli"}, "username": {user}, "password": {password}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/realms/master/protocol/openid-connect/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to log in to Keycloak: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return "", errInvalidLogin
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("failed to log in to Keycloak: %s", resp.Status)
+	}
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to log in to Keycloak: %w", err)
+	}
+	return body.AccessToken, nil
+}
+
+// keycloakAdmin calls the admin REST API, sending in and decoding the
+// response into out when they are not nil.
+func keycloakAdmin(ctx context.Context, method, url, token string, in, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Keycloak admin API: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("Keycloak admin API %s %s: %s", method, req.URL.Path, resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,56 @@
+package docker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"judo-cli-module/internal/config"
+)
+
+// fakeKeycloak accepts the admin login with *password and lets the admin
+// reset it.
+func fakeKeycloak(t *testing.T, password *string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /auth/realms/master/protocol/openid-connect/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("username") != "admin" || r.FormValue("password") != *password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "token"})
+	})
+	mux.HandleFunc("GET /auth/admin/realms/master/users", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode([]map[string]string{{"id": "42"}})
+	})
+	mux.HandleFunc("PUT /auth/admin/realms/master/users/42/reset-password", func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Value string }
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*password = body.Value
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSyncKeycloakAdminPassword(t *testing.T) {
+	creds := config.Credentials{KeycloakAdminUser: "admin", KeycloakAdminPassword: "generated"}
+
+	// a Keycloak created by an earlier version gets the configured password
+	password := legacyKeycloakAdminPassword
+	srv := fakeKeycloak(t, &password)
+	assert.NoError(t, syncKeycloakAdminPassword(t.Context(), srv.URL+"/auth", creds))
+	assert.Equal(t, "generated", password)
+
+	// then it is left alone
+	assert.NoError(t, syncKeycloakAdminPassword(t.Context(), srv.URL+"/auth", creds))
+
+	// an unknown password is reported instead of guessed
+	password = "changed-in-the-console"
+	err := syncKeycloakAdminPassword(t.Context(), srv.URL+"/auth", creds)
+	assert.ErrorContains(t, err, "keycloak_admin_password")
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"context"
+	"os"
+	"sort"
+
+	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/rollback"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+)
+
+// Labels attached to every container, volume and network created by the CLI.
+const (
+	LabelManaged = "hu.blackbelt.judo.managed"
+	LabelProject = "hu.blackbelt.judo.project"
+	LabelApp     = "hu.blackbelt.judo.app"
+	LabelProfile = "hu.blackbelt.judo.profile"
+	LabelService = "hu.blackbelt.judo.service"
+	LabelVersion = "hu.blackbelt.judo.cli-version"
+)
+
+// CLIVersion is recorded in LabelVersion; set by main.
+var CLIVersion = "dev"
+
+// Labels returns the labels of a resource with the given service role.
+func Labels(cfg *config.Config, service string) map[string]string {
+	return map[string]string{
+		LabelManaged: "true",
+		LabelProject: cfg.Root(),
+		LabelApp:     cfg.AppName,
+		LabelProfile: cfg.Profile,
+		LabelService: service,
+		LabelVersion: CLIVersion,
+	}
+}
+
+// Resource kinds reported by ListManaged.
+const (
+	KindContainer = "container"
+	KindVolume    = "volume"
+	KindNetwork   = "network"
+)
+
+// Resource is a labelled Docker object managed by the CLI.
+type Resource struct {
+	Kind    string
+	Name    string
+	Project string
+	App     string
+	Profile string
+	Service string
+	State   string // containers only
+	Image   string // containers only
+}
+
+// Orphan reports whether the project directory of the resource is gone.
+func (r Resource) Orphan() bool {
+	if r.Project == "" {
+		return true
+	}
+	_, err := os.Stat(r.Project)
+	return os.IsNotExist(err)
+}
+
+// ListManaged returns every JUDO-managed container, volume and network on
+// the host, sorted by project, kind and name.
+func ListManaged(ctx context.Context) ([]Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	managed := filters.NewArgs(filters.Arg("label", LabelManaged+"=true"))
+	var resources []Resource
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: managed})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	for _, c := range containers {
+		r := resourceFromLabels(KindContainer, containerName(c.Names), c.Labels)
+		r.State, r.Image = c.State, c.Image
+		resources = append(resources, r)
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: managed})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	for _, v := range volumes.Volumes {
+		resources = append(resources, resourceFromLabels(KindVolume, v.Name, v.Labels))
+	}
+
+	networks, err := cli.NetworkList(ctx, network.ListOptions{Filters: managed})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	for _, n := range networks {
+		resources = append(resources, resourceFromLabels(KindNetwork, n.Name, n.Labels))
+	}
+
+	kindOrder := map[string]int{KindContainer: 0, KindVolume: 1, KindNetwork: 2}
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.Profile != b.Profile {
+			return a.Profile < b.Profile
+		}
+		if a.Kind != b.Kind {
+			return kindOrder[a.Kind] < kindOrder[b.Kind]
+		}
+		return a.Name < b.Name
+	})
+	return resources, nil
+}
+
+// RemoveResource force-removes a managed resource. Containers have to be
+// removed before the volumes and networks they use.
+func RemoveResource(ctx context.Context, r Resource) error {
+	ctx, cancel := context.WithTimeout(ctx, stopTimeout)
+	defer cancel()
+	switch r.Kind {
+	case KindContainer:
+		return cli.ContainerRemove(ctx, r.Name, container.RemoveOptions{Force: true})
+	case KindVolume:
+		return cli.VolumeRemove(ctx, r.Name, true)
+	default:
+		return cli.NetworkRemove(ctx, r.Name)
+	}
+}
+
+// ensureVolume creates a labelled named volume unless it already exists.
+// Volumes created implicitly by a bind would carry no labels.
+func ensureVolume(ctx context.Context, name string, labels map[string]string) error {
+	apiCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	if _, err := cli.VolumeInspect(apiCtx, name); err == nil {
+		return nil
+	}
+	if _, err := cli.VolumeCreate(apiCtx, volume.CreateOptions{Name: name, Labels: labels}); err != nil {
+		return err
+	}
+	rollback.Record(ctx, "volume "+name, func(ctx context.Context) error { return RemoveDockerVolume(ctx, name) })
+	return nil
+}
+
+func resourceFromLabels(kind, name string, labels map[string]string) Resource {
+	return Resource{
+		Kind:    kind,
+		Name:    name,
+		Project: labels[LabelProject],
+		App:     labels[LabelApp],
+		Profile: labels[LabelProfile],
+		Service: labels[LabelService],
+	}
+}
+
+func containerName(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0][1:]
+}
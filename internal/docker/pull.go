@@ -0,0 +1,193 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/utils"
+
+	"github.com/docker/docker/api/types/image"
+)
+
+// Image pull policies accepted by --pull.
+const (
+	PullMissing = "missing"
+	PullAlways  = "always"
+)
+
+// EnsureImage makes ref available locally. With the default policy an image
+// that is already present is used as is; --pull=always pulls it regardless.
+// In --offline mode nothing is ever pulled.
+func EnsureImage(ctx context.Context, ref string) error {
+	if config.Options.Offline {
+		if imageExists(ctx, ref) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s is missing locally and --offline is set; import it with 'judo images load <bundle.tar>'", ErrImagePull, ref)
+	}
+	switch config.Options.PullPolicy {
+	case "", PullMissing:
+		if imageExists(ctx, ref) {
+			return nil
+		}
+	case PullAlways:
+	default:
+		return fmt.Errorf("invalid --pull value %q (use %s or %s)", config.Options.PullPolicy, PullMissing, PullAlways)
+	}
+	return pullImage(ctx, ref)
+}
+
+func imageExists(ctx context.Context, ref string) bool {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	_, err := cli.ImageInspect(ctx, ref)
+	return err == nil
+}
+
+func pullImage(ctx context.Context, ref string) error {
+	fmt.Printf("Pulling %s...\n", ref)
+	reader, err := cli.ImagePull(ctx, ref, image.PullOptions{})
+	if err != nil {
+		if err := apiError(err); errors.Is(err, ErrDaemonUnreachable) {
+			return err
+		}
+		return fmt.Errorf("%w: pulling %s: %w", ErrImagePull, ref, err)
+	}
+	defer reader.Close()
+	if err := renderPullProgress(reader, os.Stdout, utils.IsTerminal(os.Stdout)); err != nil {
+		return fmt.Errorf("%w: pulling %s: %w", ErrImagePull, ref, err)
+	}
+	return nil
+}
+
+// pullMessage is one entry of the JSON stream returned by the image pull and
+// load APIs.
+type pullMessage struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Stream   string `json:"stream"`
+	Progress struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail"`
+	Error       string `json:"error"`
+	ErrorDetail struct {
+		Message string `json:"message"`
+	} `json:"errorDetail"`
+}
+
+// layerDone lists the layer states worth a line when not on a terminal.
+var layerDone = map[string]bool{"Already exists": true, "Download complete": true, "Pull complete": true}
+
+// pullProgress renders the pull stream: on a terminal every layer gets a
+// progress bar that is redrawn in place, otherwise only completed steps are
+// printed so CI logs stay short.
+type pullProgress struct {
+	w      io.Writer
+	tty    bool
+	order  []string
+	layers map[string]pullMessage
+	drawn  int
+}
+
+func renderPullProgress(r io.Reader, w io.Writer, tty bool) error {
+	p := &pullProgress{w: w, tty: tty, layers: map[string]pullMessage{}}
+	dec := json.NewDecoder(r)
+	for {
+		var m pullMessage
+		if err := dec.Decode(&m); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if m.ErrorDetail.Message != "" {
+			return errors.New(m.ErrorDetail.Message)
+		}
+		if m.Error != "" {
+			return errors.New(m.Error)
+		}
+		p.handle(m)
+	}
+}
+
+func (p *pullProgress) handle(m pullMessage) {
+	if m.ID == "" || strings.HasPrefix(m.Status, "Pulling from") {
+		// Digest and summary lines; the header is printed by pullImage
+		if m.ID == "" && m.Status != "" {
+			fmt.Fprintf(p.w, "  %s\n", m.Status)
+			p.drawn = 0
+		}
+		return
+	}
+
+	prev, known := p.layers[m.ID]
+	if !known {
+		p.order = append(p.order, m.ID)
+	}
+	p.layers[m.ID] = m
+	if p.tty {
+		p.redraw()
+	} else if layerDone[m.Status] && prev.Status != m.Status {
+		fmt.Fprintf(p.w, "  %s: %s\n", m.ID, m.Status)
+	}
+}
+
+func (p *pullProgress) redraw() {
+	if p.drawn > 0 {
+		fmt.Fprintf(p.w, "\x1b[%dA", p.drawn)
+	}
+	for _, id := range p.order {
+		fmt.Fprintf(p.w, "\x1b[2K  %s\n", layerLine(p.layers[id]))
+	}
+	p.drawn = len(p.order)
+}
+
+func layerLine(m pullMessage) string {
+	line := fmt.Sprintf("%s: %-18s", m.ID, m.Status)
+	if (m.Status == "Downloading" || m.Status == "Extracting") && m.Progress.Total > 0 {
+		line += " " + progressBar(m.Progress.Current, m.Progress.Total, 30) +
+			fmt.Sprintf(" %s/%s", humanSize(m.Progress.Current), humanSize(m.Progress.Total))
+	}
+	return line
+}
+
+func progressBar(current, total int64, width int) string {
+	filled := int(int64(width) * min(current, total) / total)
+	bar := strings.Repeat("=", filled)
+	if filled < width {
+		bar += ">" + strings.Repeat(" ", width-filled-1)
+	}
+	return "[" + bar + "]"
+}
+
+func humanSize(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	value, suffix := float64(n), "B"
+	for _, s := range []string{"kB", "MB", "GB", "TB"} {
+		value /= unit
+		suffix = s
+		if value < unit {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f%s", value, suffix)
+}
@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pullStream = `{"status":"Pulling from library/postgres","id":"16.2"}
+{"status":"Pulling fs layer","progressDetail":{},"id":"a1"}
+{"status":"Already exists","progressDetail":{},"id":"b2"}
+{"status":"Downloading","progressDetail":{"current":500,"total":1000},"id":"a1"}
+{"status":"Download complete","progressDetail":{},"id":"a1"}
+{"status":"Extracting","progressDetail":{"current":1000,"total":1000},"id":"a1"}
+{"status":"Pull complete","progressDetail":{},"id":"a1"}
+{"status":"Digest: sha256:abc"}
+{"status":"Status: Downloaded newer image for postgres:16.2"}
+`
+
+func TestRenderPullProgressPlainPrintsFinishedSteps(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, renderPullProgress(strings.NewReader(pullStream), &out, false))
+
+	assert.Equal(t, "  b2: Already exists\n"+
+		"  a1: Download complete\n"+
+		"  a1: Pull complete\n"+
+		"  Digest: sha256:abc\n"+
+		"  Status: Downloaded newer image for postgres:16.2\n", out.String())
+}
+
+func TestRenderPullProgressTerminalDrawsBars(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, renderPullProgress(strings.NewReader(pullStream), &out, true))
+
+	assert.Contains(t, out.String(), "[===============>              ] 500B/1.0kB")
+	assert.Contains(t, out.String(), "\x1b[2A")
+}
+
+func TestRenderPullProgressReturnsRegistryError(t *testing.T) {
+	stream := `{"status":"Pulling from library/postgres","id":"99"}
+{"errorDetail":{"message":"manifest for postgres:99 not found: manifest unknown"},"error":"manifest for postgres:99 not found"}
+`
+	err := renderPullProgress(strings.NewReader(stream), &bytes.Buffer{}, false)
+	require.Error(t, err)
+	assert.Equal(t, "manifest for postgres:99 not found: manifest unknown", err.Error())
+}
+
+func TestHumanSize(t *testing.T) {
+	assert.Equal(t, "999B", humanSize(999))
+	assert.Equal(t, "1.5MB", humanSize(1_500_000))
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"judo-cli-module/internal/readiness"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// logTail is the number of container log lines attached to readiness errors.
+const logTail = 30
+
+// WaitForContainer waits until probe succeeds for the named container. It
+// gives up early when the container stops, and attaches the last container
+// log lines to the returned error.
+func WaitForContainer(ctx context.Context, service, name string, timeout time.Duration, probe readiness.Probe) error {
+	err := readiness.Wait(ctx, service, timeout, func(ctx context.Context) error {
+		info, err := cli.ContainerInspect(ctx, name)
+		if err != nil {
+			return err
+		}
+		if info.State != nil && !info.State.Running && !info.State.Restarting {
+			return fmt.Errorf("%w: container %s is %s (exit code %d)", readiness.ErrStopped, name, info.State.Status, info.State.ExitCode)
+		}
+		return probe(ctx)
+	})
+	if err == nil {
+		return nil
+	}
+	if logs := ContainerLogTail(name, logTail); logs != "" {
+		return fmt.Errorf("%w\nLast %d log lines of %s:\n%s", err, logTail, name, logs)
+	}
+	return err
+}
+
+// ContainerLogTail returns the last lines of a container's output. It does
+// not take the caller's context: the logs are wanted for the error report
+// even when the wait was interrupted.
+func ContainerLogTail(name string, lines int) string {
+	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	defer cancel()
+	reader, err := cli.ContainerLogs(ctx, name, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       fmt.Sprint(lines),
+	})
+	if err != nil {
+		return ""
+	}
+	defer reader.Close()
+	var out bytes.Buffer
+	if _, err := stdcopy.StdCopy(&out, &out, reader); err != nil {
+		// not multiplexed (tty container)
+		raw, _ := io.ReadAll(reader)
+		out.Write(raw)
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
+
+// ExecProbe is ready when cmd exits with 0 inside the container.
+func ExecProbe(name string, cmd ...string) readiness.Probe {
+	return func(ctx context.Context) error {
+		exec, err := cli.ContainerExecCreate(ctx, name, container.ExecOptions{
+			AttachStdout: true,
+			AttachStderr: true,
+			Cmd:          cmd,
+		})
+		if err != nil {
+			return err
+		}
+		resp, err := cli.ContainerExecAttach(ctx, exec.ID, container.ExecStartOptions{})
+		if err != nil {
+			return err
+		}
+		var out bytes.Buffer
+		_, _ = stdcopy.StdCopy(&out, &out, resp.Reader)
+		resp.Close()
+
+		info, err := cli.ContainerExecInspect(ctx, exec.ID)
+		if err != nil {
+			return err
+		}
+		if info.ExitCode != 0 {
+			return fmt.Errorf("%s: %s", cmd[0], strings.TrimSpace(out.String()))
+		}
+		return nil
+	}
+}
+
+// postgresHealthcheck runs pg_isready over TCP: while the entrypoint
+// initializes the database the server only listens on the unix socket.
+func postgresHealthcheck() *container.HealthConfig {
+	return &container.HealthConfig{
+		Test:        []string{"CMD-SHELL", `pg_isready -h 127.0.0.1 -U "$POSTGRES_USER" -d "$POSTGRES_DB"`},
+		Interval:    5 * time.Second,
+		Timeout:     3 * time.Second,
+		StartPeriod: 10 * time.Second,
+		Retries:     5,
+	}
+}
+
+// keycloakHealthcheck asks for the master realm; the image has no curl, so
+// the request is written through bash's /dev/tcp.
+func keycloakHealthcheck(port int) *container.HealthConfig {
+	script := fmt.Sprintf(`exec 3<>/dev/tcp/127.0.0.1/%d && `+
+		`printf 'GET /auth/realms/master HTTP/1.1\r\nHost: localhost\r\nConnection: close\r\n\r\n' >&3 && `+
+		`head -1 <&3 | grep -q ' 200'`, port)
+	return &container.HealthConfig{
+		Test:        []string{"CMD", "bash", "-c", script},
+		Interval:    10 * time.Second,
+		Timeout:     5 * time.Second,
+		StartPeriod: 30 * time.Second,
+		Retries:     10,
+	}
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/utils"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+)
+
+// LabelSettings records the ContainerSettings a container was created with,
+// so drift from the configuration can be detected later.
+const LabelSettings = "hu.blackbelt.judo.settings"
+
+// applySettings adds the configured limits, environment, arguments and mounts
+// to a container definition and records them in LabelSettings.
+func applySettings(cc *container.Config, hc *container.HostConfig, s config.ContainerSettings, root string) error {
+	memory, err := config.ParseMemory(s.Memory)
+	if err != nil {
+		return err
+	}
+	cpus, err := config.ParseCPUs(s.CPUs)
+	if err != nil {
+		return err
+	}
+	hc.Memory = memory
+	hc.NanoCPUs = cpus
+	hc.Binds = append(hc.Binds, s.Binds(root)...)
+	cc.Env = append(cc.Env, s.Env...)
+	cc.Cmd = append(cc.Cmd, s.Args...)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	cc.Labels[LabelSettings] = string(data)
+	return nil
+}
+
+// LabelDBCredentials records a hash of the database login a container was
+// created with. The login is passed in the environment, so a container whose
+// label differs can no longer connect once the role was changed.
+const LabelDBCredentials = "hu.blackbelt.judo.db-credentials"
+
+// DBCredentialsLabels returns the labels a container connecting to the
+// PostgreSQL database of the project must carry; none with another dbtype.
+func DBCredentialsLabels(cfg *config.Config, creds config.Credentials) map[string]string {
+	if cfg.DBType != "postgresql" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(creds.DBUser + "\x00" + creds.DBPassword))
+	return map[string]string{LabelDBCredentials: hex.EncodeToString(sum[:])}
+}
+
+// ContainerDrift compares an existing container with the configured image,
+// published port, settings and required labels and describes every
+// difference. Containers created before settings were recorded count as
+// created with no settings.
+func ContainerDrift(ctx context.Context, name, image string, port int, s config.ContainerSettings, required map[string]string) ([]string, error) {
+	drift, _, err := inspectDrift(ctx, name, image, port, s, required)
+	return drift, err
+}
+
+func inspectDrift(ctx context.Context, name, image string, port int, s config.ContainerSettings, required map[string]string) ([]string, bool, error) {
+	apiCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	info, err := cli.ContainerInspect(apiCtx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	return containerDrift(name, info.Config, info.HostConfig, image, port, s, required)
+}
+
+// containerDrift describes how a container differs from the configuration.
+// stale tells that a required label differs, so the container cannot work as
+// is and has to be recreated.
+func containerDrift(name string, cc *container.Config, hc *container.HostConfig, image string, port int, s config.ContainerSettings, required map[string]string) (drift []string, stale bool, err error) {
+	if cc.Image != image {
+		drift = append(drift, fmt.Sprintf("image: %s, configured %s", cc.Image, image))
+	}
+	if published := publishedPorts(hc.PortBindings); !slices.Contains(published, strconv.Itoa(port)) {
+		drift = append(drift, fmt.Sprintf("port: %s, configured %d", orNone(strings.Join(published, ",")), port))
+	}
+	keys := make([]string, 0, len(required))
+	for key := range required {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if cc.Labels[key] == required[key] {
+			continue
+		}
+		stale = true
+		if key == LabelDBCredentials {
+			drift = append(drift, "database credentials changed")
+		} else {
+			drift = append(drift, key+" changed")
+		}
+	}
+	var actual config.ContainerSettings
+	if raw := cc.Labels[LabelSettings]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &actual); err != nil {
+			return nil, false, fmt.Errorf("invalid %s label on %s: %w", LabelSettings, name, err)
+		}
+	}
+	return append(drift, settingsDrift(actual, s)...), stale, nil
+}
+
+func publishedPorts(bindings nat.PortMap) []string {
+	var ports []string
+	for _, bs := range bindings {
+		for _, b := range bs {
+			ports = append(ports, b.HostPort)
+		}
+	}
+	sort.Strings(ports)
+	return ports
+}
+
+func settingsDrift(actual, configured config.ContainerSettings) []string {
+	var drift []string
+	diff := func(what, a, c string) {
+		if a != c {
+			drift = append(drift, fmt.Sprintf("%s: %s, configured %s", what, orNone(a), orNone(c)))
+		}
+	}
+	diff("memory", actual.Memory, configured.Memory)
+	diff("cpus", actual.CPUs, configured.CPUs)
+	if !slices.Equal(actual.Env, configured.Env) {
+		drift = append(drift, "extra environment changed")
+	}
+	diff("args", strings.Join(actual.Args, " "), strings.Join(configured.Args, " "))
+	diff("mounts", strings.Join(actual.Mounts, " "), strings.Join(configured.Mounts, " "))
+	return drift
+}
+
+func orNone(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
+
+type recreateKey struct{}
+
+// WithRecreate returns a context asking to recreate the named containers on
+// drift as if --recreate was given, e.g. because their published port moved.
+// Other containers are left to --recreate.
+func WithRecreate(ctx context.Context, names ...string) context.Context {
+	if len(names) == 0 {
+		return ctx
+	}
+	set := map[string]bool{}
+	if prev, ok := ctx.Value(recreateKey{}).(map[string]bool); ok {
+		maps.Copy(set, prev)
+	}
+	for _, name := range names {
+		set[name] = true
+	}
+	return context.WithValue(ctx, recreateKey{}, set)
+}
+
+func recreateRequested(ctx context.Context, name string) bool {
+	set, _ := ctx.Value(recreateKey{}).(map[string]bool)
+	return set[name]
+}
+
+// reconcileContainer checks an existing container for drift. With --recreate,
+// or when WithRecreate names it, a drifted container is removed so the caller
+// creates it again; its data lives in named volumes and is kept. A container
+// missing a required label value is always recreated. Otherwise the
+// differences are printed and the container is used as is.
+func reconcileContainer(ctx context.Context, name, image string, port int, s config.ContainerSettings, required map[string]string) error {
+	drift, stale, err := inspectDrift(ctx, name, image, port, s, required)
+	if err != nil || len(drift) == 0 {
+		return err
+	}
+	if config.Options.Recreate || stale || recreateRequested(ctx, name) {
+		fmt.Printf("Recreating %s (%s)...\n", name, strings.Join(drift, "; "))
+		return RemoveDockerInstance(ctx, name)
+	}
+	fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  %s differs from the configuration:\x1b[0m\n", name)
+	for _, d := range drift {
+		fmt.Fprintf(utils.Stdout, "\x1b[33m   • %s\x1b[0m\n", d)
+	}
+	fmt.Fprintf(utils.Stdout, "\x1b[33m   Run 'judo start --recreate' to recreate it (volumes are kept)\x1b[0m\n")
+	return nil
+}
@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"judo-cli-module/internal/config"
+)
+
+func TestApplySettings(t *testing.T) {
+	cc := &container.Config{Cmd: []string{"postgres"}, Env: []string{"A=1"}, Labels: map[string]string{}}
+	hc := &container.HostConfig{Binds: []string{"data:/data"}}
+	s := config.ContainerSettings{Memory: "1g", CPUs: "2", Env: []string{"TZ=UTC"}, Args: []string{"-c", "log_statement=all"}, Mounts: []string{"conf:/conf:ro"}}
+
+	require.NoError(t, applySettings(cc, hc, s, "/project"))
+	assert.Equal(t, int64(1<<30), hc.Memory)
+	assert.Equal(t, int64(2e9), hc.NanoCPUs)
+	assert.Equal(t, []string{"data:/data", "/project/conf:/conf:ro"}, hc.Binds)
+	assert.Equal(t, []string{"A=1", "TZ=UTC"}, cc.Env)
+	assert.Equal(t, []string{"postgres", "-c", "log_statement=all"}, []string(cc.Cmd))
+	assert.JSONEq(t, `{"memory":"1g","cpus":"2","env":["TZ=UTC"],"args":["-c","log_statement=all"],"mounts":["conf:/conf:ro"]}`, cc.Labels[LabelSettings])
+}
+
+func TestSettingsDrift(t *testing.T) {
+	assert.Empty(t, settingsDrift(config.ContainerSettings{}, config.ContainerSettings{}))
+
+	drift := settingsDrift(
+		config.ContainerSettings{Memory: "512m", Env: []string{"SECRET=a"}},
+		config.ContainerSettings{Memory: "1g", Env: []string{"SECRET=b"}, Args: []string{"-c", "x=1"}},
+	)
+	assert.Equal(t, []string{
+		"memory: 512m, configured 1g",
+		"extra environment changed",
+		"args: none, configured -c x=1",
+	}, drift)
+}
+
+func TestKeycloakDriftAfterDBPasswordChange(t *testing.T) {
+	cfg := &config.Config{DBType: "postgresql"}
+	created := DBCredentialsLabels(cfg, config.Credentials{DBUser: "shop", DBPassword: "old"})
+	cc := &container.Config{Image: "keycloak:26", Labels: created}
+	hc := &container.HostConfig{PortBindings: nat.PortMap{"8080/tcp": []nat.PortBinding{{HostPort: "8080"}}}}
+
+	drift, stale, err := containerDrift("keycloak-shop", cc, hc, "keycloak:26", 8080, config.ContainerSettings{}, created)
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+	assert.False(t, stale)
+
+	changed := DBCredentialsLabels(cfg, config.Credentials{DBUser: "shop", DBPassword: "new"})
+	drift, stale, err = containerDrift("keycloak-shop", cc, hc, "keycloak:26", 8080, config.ContainerSettings{}, changed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"database credentials changed"}, drift)
+	assert.True(t, stale)
+
+	assert.Nil(t, DBCredentialsLabels(&config.Config{DBType: "hsqldb"}, config.Credentials{DBPassword: "x"}))
+}
+
+func TestWithRecreateOnlyNamesMovedContainers(t *testing.T) {
+	ctx := WithRecreate(context.Background(), "keycloak-shop")
+	assert.True(t, recreateRequested(ctx, "keycloak-shop"))
+	assert.False(t, recreateRequested(ctx, "postgres-shop"))
+	assert.False(t, config.Options.Recreate)
+
+	ctx = WithRecreate(ctx, "postgres-shop")
+	assert.True(t, recreateRequested(ctx, "keycloak-shop"))
+	assert.True(t, recreateRequested(ctx, "postgres-shop"))
+	assert.False(t, recreateRequested(context.Background(), "postgres-shop"))
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"judo-cli-module/internal/config"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+)
+
+// LegacyVolume is a PostgreSQL volume this project and profile created under
+// a name that no longer matches the configuration, e.g. after schema_name or
+// one of its aliases changed.
+type LegacyVolume struct {
+	Name    string
+	Current string // the name the configuration gives it now
+}
+
+// LegacyPostgresVolumes lists the PostgreSQL volumes of this project and
+// profile whose names differ from the current ones: volumes labelled with the
+// project root and profile, and unlabelled volumes named like released
+// versions named them. Volumes labelled for another project are never
+// considered.
+func LegacyPostgresVolumes(ctx context.Context, cfg *config.Config) ([]LegacyVolume, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	labelled, err := cli.VolumeList(ctx, volume.ListOptions{Filters: filters.NewArgs(
+		filters.Arg("label", LabelProject+"="+cfg.Root()),
+		filters.Arg("label", LabelProfile+"="+cfg.Profile),
+		filters.Arg("label", LabelService+"=postgres-data"),
+	)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Docker volumes: %w", apiError(err))
+	}
+	names := make([]string, 0, len(labelled.Volumes))
+	for _, v := range labelled.Volumes {
+		names = append(names, v.Name)
+	}
+
+	n := cfg.Names()
+	args := filters.NewArgs()
+	for _, name := range n.LegacyPostgresVolumes {
+		args.Add("name", name)
+	}
+	// the name filter matches substrings, the exact names are checked below
+	unlabelled, err := cli.VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Docker volumes: %w", apiError(err))
+	}
+	names = append(names, unlabelledVolumes(unlabelled.Volumes, n.LegacyPostgresVolumes)...)
+	return legacyVolumes(names, n), nil
+}
+
+// unlabelledVolumes returns the volumes named exactly one of names that carry
+// no project label.
+func unlabelledVolumes(volumes []*volume.Volume, names []string) []string {
+	var found []string
+	for _, v := range volumes {
+		if _, ok := v.Labels[LabelProject]; ok || !slices.Contains(names, v.Name) {
+			continue
+		}
+		found = append(found, v.Name)
+	}
+	return found
+}
+
+// legacyVolumes pairs volume names with the current PostgreSQL volume of the
+// same role, told apart by the _postgresql_db and _postgresql_data suffixes.
+func legacyVolumes(names []string, n config.ResourceNames) []LegacyVolume {
+	current := n.PostgresVolumes()
+	var legacy []LegacyVolume
+	for _, name := range names {
+		if slices.Contains(current, name) {
+			continue
+		}
+		for _, c := range current {
+			if suffix := c[strings.LastIndex(c, "_postgresql_"):]; strings.HasSuffix(name, suffix) {
+				legacy = append(legacy, LegacyVolume{Name: name, Current: c})
+			}
+		}
+	}
+	sort.Slice(legacy, func(i, j int) bool { return legacy[i].Name < legacy[j].Name })
+	return legacy
+}
+
+// AdoptLegacyVolumes copies the data of legacy PostgreSQL volumes into the
+// current ones when those do not exist yet, so an existing database survives
+// a name change. StartPostgres calls it only before the database volume is
+// created; once that exists, the adoption is done. Docker cannot rename a volume, and the source is never
+// removed: it is left for the user to delete once the data is verified. A
+// legacy volume is only reported when the current volume exists already,
+// another legacy volume has the same role or a container still uses it.
+func AdoptLegacyVolumes(ctx context.Context, cfg *config.Config, w io.Writer) error {
+	legacy, err := LegacyPostgresVolumes(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	sources := map[string][]string{}
+	for _, v := range legacy {
+		sources[v.Current] = append(sources[v.Current], v.Name)
+	}
+	for _, v := range legacy {
+		if DockerVolumeExists(ctx, v.Current) {
+			fmt.Fprintf(w, "\x1b[33m⚠️  Volume %s of this project is no longer used, %s holds the data; remove it with 'docker volume rm %s'\x1b[0m\n", v.Name, v.Current, v.Name)
+			continue
+		}
+		if len(sources[v.Current]) > 1 {
+			fmt.Fprintf(w, "\x1b[33m⚠️  Volumes %s could all become %s; none is adopted, remove the ones not needed with 'docker volume rm'\x1b[0m\n", strings.Join(sources[v.Current], ", "), v.Current)
+			continue
+		}
+		users, err := volumeUsers(ctx, v.Name)
+		if err != nil {
+			return err
+		}
+		if len(users) > 0 {
+			fmt.Fprintf(w, "\x1b[33m⚠️  Volume %s of this project is used by %s and is not adopted as %s\x1b[0m\n", v.Name, strings.Join(users, ", "), v.Current)
+			continue
+		}
+		fmt.Fprintf(w, "Copying volume %s to %s...\n", v.Name, v.Current)
+		if err := copyVolume(ctx, cfg, v.Name, v.Current); err != nil {
+			_ = RemoveDockerVolume(context.WithoutCancel(ctx), v.Current)
+			return fmt.Errorf("failed to copy volume %s to %s: %w", v.Name, v.Current, err)
+		}
+		fmt.Fprintf(w, "\x1b[33m⚠️  Volume %s was kept; remove it with 'docker volume rm %s' once the data in %s is verified\x1b[0m\n", v.Name, v.Name, v.Current)
+	}
+	return nil
+}
+
+// volumeUsers lists the containers, running or not, that mount the volume.
+func volumeUsers(ctx context.Context, name string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	containers, err := cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("volume", name)),
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	users := make([]string, 0, len(containers))
+	for _, c := range containers {
+		users = append(users, containerName(c.Names))
+	}
+	return users, nil
+}
+
+// copyVolume copies the contents of volume from into a new volume to, using
+// the PostgreSQL image so no extra image has to be pulled.
+func copyVolume(ctx context.Context, cfg *config.Config, from, to string) error {
+	image := cfg.PostgresImageRef()
+	if err := EnsureImage(ctx, image); err != nil {
+		return err
+	}
+	if err := ensureVolume(ctx, to, Labels(cfg, "postgres-data")); err != nil {
+		return err
+	}
+	cc := &container.Config{
+		Image:      image,
+		Entrypoint: []string{"sh", "-c"},
+		Cmd:        []string{"cp -a /from/. /to/"},
+		Labels:     Labels(cfg, "volume-migration"),
+	}
+	hc := &container.HostConfig{Binds: []string{from + ":/from:ro", to + ":/to"}}
+	apiCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := cli.ContainerCreate(apiCtx, cc, hc, nil, nil, "")
+	if err != nil {
+		return apiError(err)
+	}
+	defer func() { _ = RemoveDockerInstance(context.WithoutCancel(ctx), resp.ID) }()
+	if err := cli.ContainerStart(apiCtx, resp.ID, container.StartOptions{}); err != nil {
+		return apiError(err)
+	}
+
+	statusCh, errCh := cli.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		return apiError(err)
+	case status := <-statusCh:
+		if status.StatusCode != 0 {
+			return fmt.Errorf("copy exited with code %d: %s", status.StatusCode, ContainerLogTail(resp.ID, 10))
+		}
+	}
+	return nil
+}
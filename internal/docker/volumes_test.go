@@ -0,0 +1,31 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/stretchr/testify/assert"
+
+	"judo-cli-module/internal/config"
+)
+
+func TestLegacyVolumes(t *testing.T) {
+	n := (&config.Config{AppName: "shop", SchemaName: "shop_db"}).Names()
+
+	legacy := legacyVolumes([]string{"shop_db_postgresql_db", "shop_postgresql_data", "shop_postgresql_db", "shop_db_postgresql_data"}, n)
+	assert.Equal(t, []LegacyVolume{
+		{Name: "shop_postgresql_data", Current: "shop_db_postgresql_data"},
+		{Name: "shop_postgresql_db", Current: "shop_db_postgresql_db"},
+	}, legacy)
+
+	assert.Empty(t, legacyVolumes([]string{"shop_db_postgresql_db", "shop_db_postgresql_data"}, n))
+}
+
+func TestUnlabelledVolumes(t *testing.T) {
+	volumes := []*volume.Volume{
+		{Name: "shop_postgresql_db"},
+		{Name: "shop_postgresql_data", Labels: map[string]string{LabelProject: "/other/project"}},
+		{Name: "myshop_postgresql_db"},
+	}
+	assert.Equal(t, []string{"shop_postgresql_db"}, unlabelledVolumes(volumes, []string{"shop_postgresql_db", "shop_postgresql_data"}))
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"judo-cli-module/internal/config"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Kinds of ServiceEvent.
+const (
+	EventDied      = "died"
+	EventOOM       = "oom"
+	EventUnhealthy = "unhealthy"
+)
+
+// ServiceEvent is a container event of the project that needs attention.
+type ServiceEvent struct {
+	Time      time.Time
+	Container string
+	Kind      string
+	ExitCode  string // only for EventDied
+}
+
+func (e ServiceEvent) String() string {
+	switch e.Kind {
+	case EventDied:
+		return fmt.Sprintf("%s exited unexpectedly (exit code %s)", e.Container, e.ExitCode)
+	case EventOOM:
+		return fmt.Sprintf("%s ran out of memory", e.Container)
+	}
+	return fmt.Sprintf("%s is %s", e.Container, e.Kind)
+}
+
+// WatchOptions configure WatchServices.
+type WatchOptions struct {
+	Restart     bool // restart containers that died or became unhealthy
+	MaxRestarts int  // per container, so a crash loop is not restarted forever
+	LogLines    int  // container log lines shown with each event
+}
+
+// WatchServices reports die, oom and unhealthy events of the project's
+// containers to w until ctx is cancelled: the PostgreSQL and Keycloak
+// containers of the profile and the services of every compose environment.
+// Containers stopped on purpose (judo stop, docker stop) are not reported.
+func WatchServices(ctx context.Context, cfg *config.Config, opts WatchOptions, w io.Writer) error {
+	belongs := projectContainers(cfg)
+	msgs, errs := cli.Events(ctx, events.ListOptions{Filters: filters.NewArgs(
+		filters.Arg("type", string(events.ContainerEventType)),
+		filters.Arg("event", string(events.ActionDie)),
+		filters.Arg("event", string(events.ActionOOM)),
+		filters.Arg("event", string(events.ActionKill)),
+		filters.Arg("event", string(events.ActionStart)),
+		filters.Arg("event", string(events.ActionHealthStatus)),
+	)})
+
+	t := newEventTracker()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return fmt.Errorf("docker event stream ended: %w", apiError(err))
+		case msg := <-msgs:
+			if !belongs(msg.Actor.Attributes) {
+				continue
+			}
+			ev, ok := t.classify(msg)
+			if !ok {
+				continue
+			}
+			reportEvent(ctx, ev, msg.Actor.ID, opts, t, w)
+		}
+	}
+}
+
+func reportEvent(ctx context.Context, ev ServiceEvent, id string, opts WatchOptions, t *eventTracker, w io.Writer) {
+	fmt.Fprintf(w, "\x1b[31m❌ %s %s\x1b[0m\n", ev.Time.Format("15:04:05"), ev)
+	if opts.LogLines > 0 {
+		if tail := ContainerLogTail(id, opts.LogLines); tail != "" {
+			fmt.Fprintf(w, "   Last lines of %s:\n", ev.Container)
+			for _, line := range strings.Split(tail, "\n") {
+				fmt.Fprintf(w, "   │ %s\n", line)
+			}
+		}
+	}
+	// the die event following an oom kill restarts the container
+	if !opts.Restart || ev.Kind == EventOOM {
+		return
+	}
+	if t.restarts[ev.Container] >= opts.MaxRestarts {
+		fmt.Fprintf(w, "\x1b[33m⚠️  %s was restarted %d times already, leaving it stopped\x1b[0m\n", ev.Container, opts.MaxRestarts)
+		return
+	}
+	t.restarts[ev.Container]++
+	fmt.Fprintf(w, "Restarting %s (%d/%d)...\n", ev.Container, t.restarts[ev.Container], opts.MaxRestarts)
+	t.stopping[ev.Container] = true // the restart of an unhealthy container kills it
+	restartCtx, cancel := context.WithTimeout(ctx, stopTimeout)
+	defer cancel()
+	if err := cli.ContainerRestart(restartCtx, id, container.StopOptions{}); err != nil {
+		fmt.Fprintf(w, "\x1b[31m❌ Failed to restart %s: %v\x1b[0m\n", ev.Container, apiError(err))
+	}
+}
+
+// projectContainers tells from the labels of a container whether it belongs
+// to the project and profile of cfg.
+func projectContainers(cfg *config.Config) func(labels map[string]string) bool {
+	composeDirs := map[string]bool{}
+	for _, env := range ComposeEnvs(cfg) {
+		composeDirs[env.Dir] = true
+	}
+	root := cfg.Root()
+	return func(labels map[string]string) bool {
+		if labels[LabelProject] == root && labels[LabelProfile] == cfg.Profile {
+			return true
+		}
+		return composeDirs[labels[composeWorkingDirLabel]]
+	}
+}
+
+// eventTracker turns the raw event stream into ServiceEvents. Docker sends
+// a kill event before the die event of a container that is stopped on
+// purpose, which tells a stop from a crash.
+type eventTracker struct {
+	stopping map[string]bool
+	restarts map[string]int
+}
+
+func newEventTracker() *eventTracker {
+	return &eventTracker{stopping: map[string]bool{}, restarts: map[string]int{}}
+}
+
+func (t *eventTracker) classify(msg events.Message) (ServiceEvent, bool) {
+	ev := ServiceEvent{Time: time.Unix(0, msg.TimeNano), Container: msg.Actor.Attributes["name"]}
+	switch msg.Action {
+	case events.ActionKill:
+		t.stopping[ev.Container] = true
+		return ev, false
+	case events.ActionStart:
+		delete(t.stopping, ev.Container)
+		return ev, false
+	case events.ActionOOM:
+		ev.Kind = EventOOM
+	case events.ActionDie:
+		if t.stopping[ev.Container] {
+			return ev, false
+		}
+		ev.Kind, ev.ExitCode = EventDied, msg.Actor.Attributes["exitCode"]
+	case events.ActionHealthStatusUnhealthy:
+		ev.Kind = EventUnhealthy
+	default:
+		return ev, false
+	}
+	return ev, true
+}
@@ -0,0 +1,62 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/stretchr/testify/assert"
+
+	"judo-cli-module/internal/config"
+)
+
+func containerEvent(action events.Action, name string, attrs map[string]string) events.Message {
+	all := map[string]string{"name": name}
+	for k, v := range attrs {
+		all[k] = v
+	}
+	return events.Message{Type: events.ContainerEventType, Action: action, Actor: events.Actor{ID: name, Attributes: all}}
+}
+
+func TestEventTrackerReportsCrashes(t *testing.T) {
+	tr := newEventTracker()
+
+	ev, ok := tr.classify(containerEvent(events.ActionDie, "postgres-app", map[string]string{"exitCode": "1"}))
+	assert.True(t, ok)
+	assert.Equal(t, EventDied, ev.Kind)
+	assert.Equal(t, "postgres-app exited unexpectedly (exit code 1)", ev.String())
+
+	ev, ok = tr.classify(containerEvent(events.ActionOOM, "keycloak-app", nil))
+	assert.True(t, ok)
+	assert.Equal(t, EventOOM, ev.Kind)
+
+	ev, ok = tr.classify(containerEvent(events.ActionHealthStatusUnhealthy, "keycloak-app", nil))
+	assert.True(t, ok)
+	assert.Equal(t, "keycloak-app is unhealthy", ev.String())
+
+	_, ok = tr.classify(containerEvent(events.ActionHealthStatusHealthy, "keycloak-app", nil))
+	assert.False(t, ok)
+}
+
+func TestEventTrackerIgnoresIntendedStops(t *testing.T) {
+	tr := newEventTracker()
+	_, ok := tr.classify(containerEvent(events.ActionKill, "postgres-app", map[string]string{"signal": "15"}))
+	assert.False(t, ok)
+	_, ok = tr.classify(containerEvent(events.ActionDie, "postgres-app", map[string]string{"exitCode": "0"}))
+	assert.False(t, ok, "die after kill is a stop")
+
+	// once started again, a die is a crash
+	_, _ = tr.classify(containerEvent(events.ActionStart, "postgres-app", nil))
+	_, ok = tr.classify(containerEvent(events.ActionDie, "postgres-app", map[string]string{"exitCode": "137"}))
+	assert.True(t, ok)
+}
+
+func TestProjectContainers(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{ModelDir: dir, AppDir: dir, Profile: "dev"}
+	belongs := projectContainers(cfg)
+
+	assert.True(t, belongs(map[string]string{LabelProject: cfg.Root(), LabelProfile: "dev"}))
+	assert.False(t, belongs(map[string]string{LabelProject: cfg.Root(), LabelProfile: "ci"}))
+	assert.False(t, belongs(map[string]string{LabelProject: "/elsewhere", LabelProfile: "dev"}))
+	assert.False(t, belongs(map[string]string{}))
+}
@@ -14,7 +14,11 @@ func RootHelp() string {
 	return `JUDO runner.
 
 USAGE: judo COMMANDS... [OPTIONS...]
-    env <env>                               Use alternate env (custom properties file). Default judo is used.
+    env <env>                               Use alternate env (custom properties file). Default judo (or $JUDO_PROFILE) is used.
+    -C --project-dir <dir>                  Run as if judo was started in <dir>. The project root is found by walking
+                                            up to the nearest directory containing judo.properties or judo-version.properties.
+    --pull missing|always                   Pull container images only when missing locally (default) or always.
+    --offline                               Never pull images; fail when an image is not available locally.
     doctor                                  Check system health and required dependencies.
         -v --verbose                        Show detailed version information for all tools.
     clean                                   Stop postgresql docker container and clear data.
@@ -56,12 +60,14 @@ USAGE: judo COMMANDS... [OPTIONS...]
     start                                   Run application with postgresql and keycloak.
         -W --skip-watch-bundles             Disable watching of bundle changes
         -K --skip-keycloak                  Skip starting keycloak.
+        --recreate                          Recreate containers whose image or settings differ from the configuration.
+        --auto-ports                        Use free ports when the configured ones are taken and remember them.
         -o "<name>=<value>,<name2>=<value2>, ... " --options "<name>=<value>,<name2>=<value2>, ..."
                                             Add options (defaults can be defined in judo.properties)
                                             Available options:
                                                runtime = karaf | compose
                                                dbtype = hsqldb | postgresql
-                                               compose_env = compose-develop | compose-postgresql-https | or any directory defined in ${MODEL_DIR}/docker
+                                               compose_env = compose-develop | compose-postgresql-https | any environment listed by 'judo compose envs'
                                                model_dir = model project directory. Default is the application's parent.
                                                karaf_port = <port>
                                                postgres_port = <port>
@@ -70,7 +76,24 @@ USAGE: judo COMMANDS... [OPTIONS...]
                                                karaf_enable_admin_user = 1
                                                java_compiler = ejc | javac. Which compuler can be used, default is ejc
     stop                                    Stop application, postgresql and keycloak. (if running)
+        --volumes                           With compose runtime, also remove the environment's volumes.
     status                                  Print status of containers
+    ps                                      List JUDO-managed containers, volumes and networks of all projects.
+        --prune-orphans                     Remove resources whose project directory no longer exists.
+    compose envs                            List the compose environments of the project; * marks the active compose_env.
+    images list                             List the images the project needs and whether they are available locally.
+    images save -o <bundle.tar>             Export the images of the project into a tar bundle.
+    images load <bundle.tar>                Import images from a bundle (use with --offline on restricted networks).
+    config show                             Print the effective configuration.
+        --origin                            Show the file/line or source each value came from.
+    config get <key>                        Print the effective value of a key.
+    config set <key> <value>                Set a key in judo.properties (or <env>.properties with -e).
+        --global                            Write to the per-user ~/.judo/config instead.
+    config unset <key>                      Remove a key from judo.properties (or <env>.properties with -e).
+        --global                            Remove from the per-user ~/.judo/config instead.
+    config validate [file...]               Report unknown keys and invalid values. Fails on errors.
+    config migrate [file]                   Rewrite dotted keys (karaf.port, ...) into canonical form.
+        --dry-run                           Only report what would be changed.
 
 
 EXAMPLES:
@@ -99,13 +122,26 @@ func StartLongHelp() string {
 
   -W --skip-watch-bundles   Disable watching of bundle changes
   -K --skip-keycloak        Skip starting keycloak.
+  --pull missing|always     Pull container images only when missing locally
+                            (default) or always.
+  --auto-ports              When a port is taken by another process, move the
+                            service to the next free port. Only moved ports are
+                            kept in .judo/state/<profile>.properties and used by
+                            stop, status, dump, schema-upgrade and later starts
+                            until 'judo clean'.
+  --recreate                Recreate the PostgreSQL/Keycloak containers when
+                            their image or settings differ from the
+                            configuration (data volumes are kept). Keycloak
+                            is always recreated when db_user or db_password
+                            changed, as it keeps the login it was created with.
   -o, --options "<k=v,k2=v2,...>"
-                            Add options (defaults can be defined in judo.properties)
+                            Add options (defaults can be defined in judo.properties
+                            or JUDO_<KEY> environment variables)
 
 Available options:
   runtime = karaf | compose
   dbtype = hsqldb | postgresql
-  compose_env = compose-develop | compose-postgresql-https | or any directory defined in ${MODEL_DIR}/docker
+  compose_env = compose-develop | compose-postgresql-https | any environment listed by 'judo compose envs'
   model_dir = model project directory. Default is the application's parent.
   karaf_port = <port>
   postgres_port = <port>
@@ -113,6 +149,37 @@ Available options:
   compose_access_ip = <alternate ip address to access app>
   karaf_enable_admin_user = 1
   java_compiler = ejc | javac (default ejc)
+  postgres_timeout, keycloak_timeout, karaf_timeout = <seconds>
+
+Readiness:
+  Each service is started only after the previous one is ready:
+  • PostgreSQL: pg_isready inside the container (default timeout 60s).
+  • Keycloak: GET /auth/realms/master returns 200 (default timeout 120s).
+  • Karaf: the HTTP port answers (default timeout 180s).
+  Containers also get a Docker HEALTHCHECK, shown by 'docker ps'. When a
+  service is not ready in time, or its container stops, start fails with the
+  last lines of the container log or of .karaf/console.out.
+
+Volumes:
+  When schema_name (or an alias such as database.schema) changes, the
+  PostgreSQL volumes get new names. Volumes labelled with this project and
+  profile under an earlier name, and the unlabelled <app>_postgresql_db and
+  <app>_postgresql_data volumes of released versions, are adopted: the start
+  that creates <schema>_postgresql_db and <schema>_postgresql_data copies
+  their data into them. The old volumes are kept; 'judo status' lists
+  them and they can be removed with 'docker volume rm' once the data is
+  verified.
+
+Images:
+  The PostgreSQL and Keycloak images are only pulled when they are not
+  available locally, unless --pull=always is given; --offline never pulls. Pull progress is shown
+  per layer on a terminal and as one line per finished layer otherwise.
+
+Interrupting:
+  Ctrl-C while start is still bringing services up removes what this start
+  created (containers, networks, volumes and the Karaf process); existing
+  containers and their data are left alone. Press Ctrl-C again to quit
+  without cleaning up.
 `
 }
 
@@ -140,11 +207,28 @@ func BuildLongHelp() string {
 func CleanLongHelp() string {
 	return `Stop postgresql docker container and clear data.
 
-This removes:
-  • All Docker containers for postgres-<schema>, keycloak-<keycloak>
-  • The Docker network <app_name>
-  • Volumes: <app>_certs, <schema>_postgresql_db, <schema>_postgresql_data, <app>_filestore
-  • Karaf dir (application/.karaf) if running in local 'karaf' runtime.
+This removes, by component:
+  postgres    container postgres-<schema>, volumes <schema>_postgresql_db and
+              <schema>_postgresql_data
+  keycloak    container keycloak-<keycloak>, volume <app>_certs
+  filestore   volume <app>_filestore
+  karaf       Karaf dir (application/.karaf) in the local 'karaf' runtime
+  compose     the containers, networks and volumes of every compose environment
+The Docker network <app_name> goes with postgres and keycloak together, the
+ports remembered by 'judo start --auto-ports' with postgres, keycloak and
+karaf together.
+
+Options:
+  --dry-run           List every resource with whether it exists and would be
+                      removed or kept; nothing is removed.
+  --only <list>       Clean only these components, e.g. --only postgres,karaf
+  --keep <list>       Clean everything except these components.
+  -y, --yes           Do not ask before removing data (the PostgreSQL and
+                      filestore volumes, compose environments). Required
+                      when stdin is not a terminal, e.g. in CI.
+
+Removal continues after a failure; the resources that could not be removed
+are listed at the end and clean exits with an error.
 `
 }
 
@@ -231,6 +315,19 @@ Behavior:
   • Ensures local PostgreSQL is up.
   • Executes 'judo-rdbms-schema:apply' against jdbc:postgresql://127.0.0.1:<port>/<schema>
     with -DschemaIgnoreModelDependency=true and -DupdateModel pointing to the generated model.
+  • Uses db_user/db_password (see 'judo config --help'). The password is not
+    put on the command line: it is written to a temporary Maven global settings
+    file (-gs, readable by the owner only, removed afterwards) whose active
+    profile sets the dbPassword property. -gs replaces the global settings, so
+    the file is a copy of conf/settings.xml of the Maven installation (found
+    through MVND_HOME, the mvnd executable, MAVEN_HOME or M2_HOME) with the
+    profile added; mirrors and proxies defined there keep working.
+    ~/.m2/settings.xml still applies.
+
+Manual check:
+  Run 'judo schema-upgrade': the plugin logs in with db_password (a wrong
+  password fails with "password authentication failed"), and
+  'ps -ef | grep judo-rdbms-schema' while it runs shows no password.
 
 Notes:
   • Works only when dbtype=postgresql.
@@ -245,16 +342,134 @@ Behavior (karaf runtime):
   • Stops Karaf if running.
   • Stops postgres-<schema> (when dbtype=postgresql).
   • Stops keycloak-<keycloak>.
+
+Behavior (compose runtime):
+  • Stops and removes the containers and networks of the compose environment
+    (like 'docker compose down'). Volumes are kept.
+
+Options:
+  --volumes   Also remove the named volumes of the compose environment.
+`
+}
+
+func LogLongHelp() string {
+	return `Display application logs.
+
+Karaf runtime:
+  Shows application/.karaf/console.out.
+
+Compose runtime:
+  judo log [service...]   Shows the logs of the given compose services, or of
+                          all services, each line prefixed with the service.
+
+Options:
+  -t --tail               Show only the last lines.
+  -f --follow             Keep streaming new log lines.
+  -n --lines <N>          Number of lines for --tail/--follow (default 50).
 `
 }
 
 func StatusLongHelp() string {
 	return `Print status of containers and local Karaf.
 
-Reports:
+Reports (karaf runtime):
   • Karaf running/not running (based on application/.karaf/bin/status).
   • PostgreSQL running/not running + container/volume existence (if dbtype=postgresql).
   • Keycloak running/not running + container existence.
+  • The image each container runs, with a warning when the container differs
+    from the configured image or container settings (see 'judo config --help').
+
+Reports (compose runtime):
+  • State and health of every service of the compose environment.
+`
+}
+
+func PsLongHelp() string {
+	return `List every JUDO-managed container, volume and network on the Docker host,
+grouped by project.
+
+Resources created by the CLI carry labels (hu.blackbelt.judo.*) with the
+project path, app name, profile, service role and CLI version. A resource is
+an orphan when its project directory no longer exists.
+
+Options:
+  --prune-orphans   Remove orphaned containers, then their volumes and networks.
+                    Orphaned volumes hold data and are only removed after
+                    confirmation; answering no prunes everything else.
+  -y, --yes         Do not ask before removing orphaned volumes. Required
+                    when stdin is not a terminal.
+
+Notes:
+  • Resources created by earlier versions have no labels and are not listed.
+`
+}
+
+func WatchServicesLongHelp() string {
+	return `Watch the Docker events of the project's containers and report when one
+exits unexpectedly, is killed for running out of memory or its healthcheck
+reports unhealthy, together with the last lines of its log. Runs until
+Ctrl-C.
+
+Watched are the PostgreSQL and Keycloak containers of the current profile and
+the services of every compose environment of the project. Containers stopped
+on purpose ('judo stop', 'docker stop') are not reported.
+
+Options:
+  --restart            Restart a container that died or became unhealthy.
+  --max-restarts <n>   Give up restarting a container after n restarts
+                       (default 3), so a crash loop is not hidden.
+  -n, --lines <n>      Log lines shown with each event (default 20).
+
+In 'judo session', 'watch on [--restart]' runs the watcher in the background
+and 'watch off' stops it.
+`
+}
+
+func ComposeLongHelp() string {
+	return `Inspect the docker compose environments of the project.
+
+Subcommands:
+  envs   List every compose environment with its directory and compose files.
+         The environment selected by compose_env is marked with *.
+
+Discovery:
+  • An environment is a directory below <model_dir>/docker or
+    <application>/docker holding compose.yaml, compose.yml,
+    docker-compose.yaml or docker-compose.yml (first match wins).
+  • An override file next to it (e.g. compose.override.yaml) is applied on top.
+  • When both layouts define the same environment, the model one is used.
+
+The same discovery is used by start, stop, status, log and clean, so every
+command works on the same environment.
+`
+}
+
+func ImagesLongHelp() string {
+	return `List, export and import the container images of the project, for
+developers working on restricted networks.
+
+Subcommands:
+  list                  Show every image the project needs, whether it is
+                        available locally and what needs it.
+  save [-o bundle.tar]  Export the images into a tar bundle (default
+                        judo-images.tar). Missing images are pulled first
+                        unless --offline is given.
+  load <bundle.tar>     Import the images of a bundle.
+
+Images of the project:
+  • PostgreSQL (postgres_image:postgres_version) when dbtype = postgresql.
+  • Keycloak (keycloak_image:keycloak_version).
+  • The image of every service of every compose environment, as resolved by
+    'docker compose config'. Services that are only built locally are skipped.
+
+Offline mode:
+  With --offline, start and the other commands never pull images; a missing
+  image is an error pointing at 'judo images load'.
+
+Examples:
+  judo images save -o judo-images.tar     # on a machine with registry access
+  judo images load judo-images.tar        # on the restricted machine
+  judo --offline start
 `
 }
 
@@ -275,7 +490,8 @@ This command verifies that all necessary tools and dependencies are available:
 
 Essential Tools (required):
   • Go - Programming language runtime
-  • Docker - Container platform (must be running)
+  • Docker - Container platform (must be running). Rootless Docker, Podman
+    and docker contexts are detected as well.
   • Maven/mvnd - Build tool (mvnd preferred)
   • Git - Version control system
 
@@ -283,6 +499,14 @@ Optional Tools:
   • Java - Required for some operations
   • SDKMAN - SDK version management
 
+Docker engine discovery (first reachable wins):
+  • DOCKER_HOST
+  • The docker context selected by DOCKER_CONTEXT or 'docker context use'
+  • /var/run/docker.sock, $XDG_RUNTIME_DIR/docker.sock (rootless),
+    ~/.docker/run/docker.sock (Desktop), ~/.colima/default/docker.sock
+  • $XDG_RUNTIME_DIR/podman/podman.sock, /run/podman/podman.sock
+  The compose runtime uses the same engine ('podman compose' for Podman).
+
 Port Availability:
   • 8080 - Keycloak default port
   • 8181 - Karaf default port  
@@ -315,3 +539,136 @@ Example:
   judo init
 `
 }
+
+func ConfigLongHelp() string {
+	return `Inspect and edit JUDO project configuration.
+
+Configuration is layered, later layers override earlier ones:
+  1. Built-in defaults
+  2. ~/.judo/config                 (per-user global settings)
+  3. judo-version.properties        (postgresql.version, keycloak.version only)
+  4. judo.properties                (project)
+  5. <profile>.properties           (selected with --env)
+  6. .judo/state/<profile>.properties
+                                    (ports chosen by 'judo start --auto-ports')
+  7. JUDO_* environment variables   (e.g. JUDO_KARAF_PORT=8282)
+  8. --options key=value,...        (judo start)
+An empty value (key= in a file or --options, JUDO_KEY= in the environment)
+resets the key to its built-in default.
+
+Every key below can be overridden from the environment as JUDO_<KEY in upper
+case>, e.g. JUDO_DBTYPE, JUDO_RUNTIME, JUDO_SCHEMA_NAME, JUDO_COMPOSE_ENV.
+JUDO_PROFILE selects the profile when -e/--env is not given.
+
+Properties files may reference other values and include further files:
+  keycloak_name = ${schema_name}                 value of another key
+  karaf_port    = ${env:KARAF_PORT}              environment variable
+  compose_env   = ${env:COMPOSE_ENV:-compose-develop}
+                                                 environment variable with default
+  include       = ports.properties               relative to the including file
+  registry      = registry.example.com           helper key: an unknown key that
+  postgres_image = ${registry}/postgres          is referenced is not reported
+The default of an environment reference may itself be a reference, e.g.
+${env:KARAF_PORT:-${base_port}}.
+Entries of an included file apply at the position of the include line.
+Undefined references and include/reference cycles are reported with the
+offending file and line.
+
+Subcommands:
+  show [--origin]     Print each effective value, optionally with the file/line
+                      or source it came from.
+  get <key>           Print the effective value of a key.
+                      show/get mask passwords unless --show-secrets is given.
+  set <key> <value>   Write a key to judo.properties, or <profile>.properties
+                      when a profile is selected with -e. Comments, blank lines
+                      and separators in the file are preserved.
+  unset <key>         Remove a key from the same file.
+                      With --global, set/unset edit ~/.judo/config, which holds
+                      personal defaults that should not be committed.
+  validate [file...]  Check the active configuration (or the given files) for
+                      unknown keys and invalid values. Exits non-zero on errors,
+                      so it can be used in CI.
+  migrate [file]      Rename dotted keys to their canonical spelling, drop
+                      overridden duplicates and report keys that cannot be
+                      mapped. Use --dry-run to only print the report.
+
+Supported keys (dotted aliases are accepted as well):
+  model_dir                 path
+  app_name                  string              app.name
+  schema_name               string              app.schema, database.schema
+  keycloak_name             string
+  runtime                   karaf | compose     runtime.mode
+  dbtype                    hsqldb | postgresql database.type
+  compose_env               string
+  compose_access_ip         string
+  karaf_port                port (1-65535)      karaf.port
+  postgres_port             port (1-65535)      postgres.port, database.port
+  keycloak_port             port (1-65535)      keycloak.port
+  karaf_enable_admin_user   true | false
+  java_compiler             ejc | javac
+
+Personal defaults (typically in ~/.judo/config):
+  default_profile           profile used when -e/--env is not given
+  color                     auto | always | never
+  maven_args                extra Maven arguments for every build
+  java_heap                 Karaf JVM heap, e.g. 1024m or 2g
+  port_offset               added to karaf_port, postgres_port and keycloak_port
+                            (ports given with --options or chosen by
+                            --auto-ports are used as is)
+
+Container images:
+  postgres_image            default postgres
+  postgres_version          default 16.2              postgresql.version
+  keycloak_image            default quay.io/keycloak/keycloak
+  keycloak_version          default 23.0              keycloak.version
+The version is used as tag unless the image already has a :tag or is pinned
+with @sha256:<digest>. postgresql.version and keycloak.version are also read
+from judo-version.properties (layer 3 above).
+Existing containers keep their image; 'judo status' warns about a mismatch
+and 'judo start --recreate' recreates them.
+
+Readiness timeouts (seconds, see 'judo start --help'):
+  postgres_timeout          default 60
+  keycloak_timeout          default 120
+  karaf_timeout             default 180
+
+Container tuning (postgres_* and keycloak_*, e.g. per profile):
+  <svc>_memory              memory limit, e.g. 512m or 2g
+  <svc>_cpus                CPU limit, e.g. 1.5
+  <svc>_env                 extra environment, space separated KEY=VALUE
+  <svc>_args                extra command arguments, e.g.
+                            postgres_args = -c shared_buffers=256MB -c log_statement=all
+  <svc>_mounts              extra bind mounts, space separated host:container[:ro];
+                            relative host paths are resolved against the project root
+Settings are applied when the container is created and recorded on it.
+When they change, 'judo start' and 'judo status' list the differences and
+'judo start --recreate' recreates the container; data volumes are kept.
+
+Credentials:
+  db_user                   database user (defaults to schema_name)
+  db_password               database password
+  keycloak_admin_password   Keycloak admin password
+Passwords that are not configured are generated once per project and kept in
+.judo/secrets.properties (readable by the owner only; do not commit it).
+Prefer JUDO_DB_PASSWORD / JUDO_KEYCLOAK_ADMIN_PASSWORD or 'set --global' over
+committed properties files. Passwords are masked by show/get unless
+--show-secrets is given, and are handed to containers and pg tools through
+the environment, never on a command line.
+Databases created by earlier versions use schema_name as password. When the
+database volume exists and no password is configured or generated yet, that
+password is kept; otherwise 'judo start' sets the configured password on the
+existing database.
+A Keycloak set up by an earlier version has the admin password 'judo';
+'judo start' changes it to keycloak_admin_password.
+
+Examples:
+  judo config show --origin
+  judo -e compose-dev config show --origin
+  judo config set karaf_port 8282
+  judo -e compose-dev config set runtime compose
+  judo config unset karaf_port
+  judo config set --global port_offset 100
+  judo config validate
+  judo config migrate --dry-run
+`
+}
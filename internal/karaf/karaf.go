@@ -1,13 +1,18 @@
 package karaf
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"judo-cli-module/internal/config"
+	"judo-cli-module/internal/readiness"
+	"judo-cli-module/internal/rollback"
 	"judo-cli-module/internal/utils"
 )
 
@@ -32,8 +37,13 @@ func KarafRunning(karafDir string) bool {
 	return strings.Contains(out, "Running") && !strings.Contains(out, "Not")
 }
 
-func StartKaraf() {
+// StartKaraf unpacks and starts Karaf in the background and waits until its
+// HTTP endpoint answers. The started process is recorded for rollback.
+func StartKaraf(ctx context.Context) error {
 	cfg := config.GetConfig()
+	if !utils.IsPortAvailable(cfg.KarafPort) {
+		return &utils.PortInUseError{Service: "Karaf", Port: cfg.KarafPort}
+	}
 	fmt.Println("Starting Karaf...")
 
 	// env like in the bash
@@ -43,25 +53,29 @@ func StartKaraf() {
 		os.Setenv("JUDO_PLATFORM_RDBMS_DB_PORT", fmt.Sprintf("%d", cfg.PostgresPort))
 	}
 	os.Setenv("JUDO_PLATFORM_RDBMS_DB_DATABASE", cfg.SchemaName)
-	os.Setenv("JUDO_PLATFORM_RDBMS_DB_USER", cfg.SchemaName)
-	os.Setenv("JUDO_PLATFORM_RDBMS_DB_PASSWORD", cfg.SchemaName)
+	creds, err := cfg.Credentials()
+	if err != nil {
+		return fmt.Errorf("failed to resolve database credentials: %w", err)
+	}
+	os.Setenv("JUDO_PLATFORM_RDBMS_DB_USER", creds.DBUser)
+	os.Setenv("JUDO_PLATFORM_RDBMS_DB_PASSWORD", creds.DBPassword)
 	os.Setenv("JUDO_PLATFORM_KEYCLOAK_AUTH_SERVER_URL", fmt.Sprintf("http://localhost:%d/auth", cfg.KeycloakPort))
 	if !config.Options.WatchBundles {
 		os.Setenv("JUDO_PLATFORM_BUNDLE_WATCHER", "false")
 	}
-	os.Setenv("EXTRA_JAVA_OPTS", "-Xms1024m -Xmx1024m -Dfile.encoding=UTF-8 -Dsun.jnu.encoding=UTF-8")
+	os.Setenv("EXTRA_JAVA_OPTS", fmt.Sprintf("-Xms%s -Xmx%s -Dfile.encoding=UTF-8 -Dsun.jnu.encoding=UTF-8", cfg.JavaHeap, cfg.JavaHeap))
 
-	karafDir := filepath.Join(cfg.ModelDir, "application", ".karaf")
+	karafDir := cfg.KarafDir
 	_ = os.RemoveAll(karafDir)
 	_ = os.MkdirAll(karafDir, 0o755)
 
-	ver := utils.GetProjectVersion()
-	tarPath := filepath.Join(cfg.ModelDir, "application", "karaf-offline", "target",
+	ver := utils.GetProjectVersion(cfg.ModelDir)
+	tarPath := filepath.Join(cfg.AppDir, "karaf-offline", "target",
 		fmt.Sprintf("%s-application-karaf-offline-%s.tar.gz", cfg.AppName, ver),
 	)
 	// extract
 	if err := utils.UntarGz(tarPath, karafDir, 1); err != nil {
-		log.Fatalf("Failed to extract Karaf archive: %v", err)
+		return fmt.Errorf("failed to extract Karaf archive: %w", err)
 	}
 
 	// Ensure karaf script is executable
@@ -89,8 +103,37 @@ func StartKaraf() {
 	ecmd.Stdout = consoleOut
 	ecmd.Stderr = consoleOut
 	if err := ecmd.Start(); err != nil {
-		log.Fatalf("Failed to start Karaf: %v", err)
+		return fmt.Errorf("failed to start Karaf: %w", err)
 	}
-
 	fmt.Printf("Karaf started (pid %d). Logs: %s\n", ecmd.Process.Pid, consoleOut.Name())
+	rollback.Record(ctx, "Karaf", func(context.Context) error {
+		// still booting, so bin/stop may not reach it yet
+		if err := ecmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			return err
+		}
+		return nil
+	})
+
+	exited := make(chan error, 1)
+	go func() { exited <- ecmd.Wait() }()
+
+	url := fmt.Sprintf("http://localhost:%d/", cfg.KarafPort)
+	httpProbe := readiness.HTTP(url, readiness.Responding)
+	timeout := time.Duration(cfg.KarafTimeout) * time.Second
+	err = readiness.Wait(ctx, "Karaf", timeout, func(ctx context.Context) error {
+		select {
+		case err := <-exited:
+			exited <- err
+			return fmt.Errorf("%w: karaf exited (%v)", readiness.ErrStopped, err)
+		default:
+		}
+		return httpProbe(ctx)
+	})
+	if err != nil {
+		if tail := utils.TailFile(consoleOut.Name(), 30); tail != "" {
+			return fmt.Errorf("%w\nLast lines of %s:\n%s", err, consoleOut.Name(), tail)
+		}
+		return err
+	}
+	return nil
 }
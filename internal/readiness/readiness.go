@@ -0,0 +1,121 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+// Package readiness waits for services to become usable, not merely for
+// their ports to accept connections.
+package readiness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Probe returns nil once the service is ready and an error describing why it
+// is not ready otherwise.
+type Probe func(ctx context.Context) error
+
+// Interval between two probe attempts.
+var Interval = time.Second
+
+// TimeoutError is returned by Wait when a service did not become ready in
+// time. Err is the last probe failure.
+type TimeoutError struct {
+	Service string
+	Timeout time.Duration
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%s was not ready after %s", e.Service, e.Timeout)
+	}
+	return fmt.Sprintf("%s was not ready after %s: %v", e.Service, e.Timeout, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error { return e.Err }
+
+// ErrStopped marks a probe failure that retrying will not fix, e.g. the
+// process or container has exited. Wait returns such failures immediately.
+var ErrStopped = errors.New("service stopped")
+
+// Wait polls probe until it succeeds, fails with ErrStopped, ctx is done or
+// timeout elapses. Progress is printed as dots.
+func Wait(ctx context.Context, service string, timeout time.Duration, probe Probe) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fmt.Printf("Waiting for %s to become ready", service)
+	defer fmt.Println()
+
+	var last error
+	for {
+		attempt, cancelAttempt := context.WithTimeout(ctx, 5*time.Second)
+		last = probe(attempt)
+		cancelAttempt()
+		if last == nil {
+			return nil
+		}
+		if errors.Is(last, ErrStopped) {
+			return last
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return &TimeoutError{Service: service, Timeout: timeout, Err: last}
+			}
+			return ctx.Err()
+		case <-time.After(Interval):
+			fmt.Print(".")
+		}
+	}
+}
+
+// TCP is ready once host:port accepts connections.
+func TCP(host string, port int) Probe {
+	return func(ctx context.Context) error {
+		var d net.Dialer
+		c, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, fmt.Sprint(port)))
+		if err != nil {
+			return err
+		}
+		return c.Close()
+	}
+}
+
+// HTTP is ready once url answers with a status accepted by ok.
+func HTTP(url string, ok func(status int) bool) Probe {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if !ok(resp.StatusCode) {
+			return fmt.Errorf("GET %s returned %s", url, resp.Status)
+		}
+		return nil
+	}
+}
+
+// StatusOK accepts 200 only.
+func StatusOK(status int) bool { return status == http.StatusOK }
+
+// Responding accepts any answer that is not a server error: the HTTP server
+// is up even if nothing is mounted at the probed path.
+func Responding(status int) bool { return status < http.StatusInternalServerError }
@@ -0,0 +1,57 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitRetriesUntilReady(t *testing.T) {
+	Interval = time.Millisecond
+	calls := 0
+	err := Wait(context.Background(), "svc", time.Second, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("starting")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestWaitTimesOutWithLastError(t *testing.T) {
+	Interval = time.Millisecond
+	err := Wait(context.Background(), "svc", 20*time.Millisecond, func(context.Context) error {
+		return errors.New("connection refused")
+	})
+	var timeout *TimeoutError
+	assert.ErrorAs(t, err, &timeout)
+	assert.Equal(t, "svc was not ready after 20ms: connection refused", err.Error())
+}
+
+func TestWaitStopsOnStoppedService(t *testing.T) {
+	Interval = time.Millisecond
+	err := Wait(context.Background(), "svc", time.Second, func(context.Context) error {
+		return ErrStopped
+	})
+	assert.ErrorIs(t, err, ErrStopped)
+}
+
+func TestHTTPProbe(t *testing.T) {
+	status := http.StatusNotFound
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	assert.Error(t, HTTP(srv.URL, StatusOK)(context.Background()))
+	assert.NoError(t, HTTP(srv.URL, Responding)(context.Background()))
+	status = http.StatusOK
+	assert.NoError(t, HTTP(srv.URL, StatusOK)(context.Background()))
+}
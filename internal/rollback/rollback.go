@@ -0,0 +1,78 @@
+/*
+ * Copyright © 2026 BlackBelt Meta Zrt.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Eclipse Public License 2.0 which is available at
+ * https://www.eclipse.org/legal/epl-2.0/
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+// Package rollback records how to undo the steps of a multi-step operation,
+// so that an interrupted 'judo start' can remove what it already created.
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Journal collects undo steps in the order their resources were created.
+type Journal struct {
+	mu    sync.Mutex
+	steps []step
+}
+
+type step struct {
+	description string
+	undo        func(ctx context.Context) error
+}
+
+type journalKey struct{}
+
+// WithJournal returns a context that carries j, so functions deep in the
+// call chain can record what they create without extra parameters.
+func WithJournal(ctx context.Context, j *Journal) context.Context {
+	return context.WithValue(ctx, journalKey{}, j)
+}
+
+// Record adds an undo step to the journal of ctx. Without a journal it does
+// nothing, so callers need not know whether a rollback is wanted.
+func Record(ctx context.Context, description string, undo func(ctx context.Context) error) {
+	j, _ := ctx.Value(journalKey{}).(*Journal)
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.steps = append(j.steps, step{description: description, undo: undo})
+}
+
+// Len returns the number of recorded steps.
+func (j *Journal) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.steps)
+}
+
+// Run undoes the recorded steps in reverse order, reporting each one to w.
+// Every step is attempted; the failures are returned together.
+func (j *Journal) Run(ctx context.Context, w io.Writer) error {
+	j.mu.Lock()
+	steps := j.steps
+	j.steps = nil
+	j.mu.Unlock()
+
+	var errs []error
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		fmt.Fprintf(w, "  Removing %s\n", s.description)
+		if err := s.undo(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.description, err))
+		}
+	}
+	return errors.Join(errs...)
+}
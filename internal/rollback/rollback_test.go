@@ -0,0 +1,49 @@
+package rollback
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunUndoesInReverseOrder(t *testing.T) {
+	j := &Journal{}
+	ctx := WithJournal(context.Background(), j)
+
+	var undone []string
+	for _, name := range []string{"network", "volume", "container"} {
+		Record(ctx, name, func(context.Context) error {
+			undone = append(undone, name)
+			return nil
+		})
+	}
+	assert.Equal(t, 3, j.Len())
+
+	var out bytes.Buffer
+	assert.NoError(t, j.Run(context.Background(), &out))
+	assert.Equal(t, []string{"container", "volume", "network"}, undone)
+	assert.Equal(t, "  Removing container\n  Removing volume\n  Removing network\n", out.String())
+	assert.Equal(t, 0, j.Len())
+}
+
+func TestRunAttemptsEveryStep(t *testing.T) {
+	j := &Journal{}
+	ctx := WithJournal(context.Background(), j)
+	calls := 0
+	Record(ctx, "a", func(context.Context) error { calls++; return nil })
+	Record(ctx, "b", func(context.Context) error { calls++; return errors.New("busy") })
+
+	err := j.Run(context.Background(), &bytes.Buffer{})
+	assert.EqualError(t, err, "b: busy")
+	assert.Equal(t, 2, calls)
+}
+
+func TestRecordWithoutJournalIsIgnored(t *testing.T) {
+	Record(context.Background(), "x", func(context.Context) error {
+		t.Fatal("must not be called")
+		return nil
+	})
+}
@@ -11,8 +11,11 @@
 package session
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -20,6 +23,7 @@ import (
 
 	"github.com/chzyer/readline"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"judo-cli-module/internal/commands"
 	"judo-cli-module/internal/config"
 	"judo-cli-module/internal/docker"
@@ -34,6 +38,11 @@ type SessionState struct {
 	CommandHistory     []string
 	StartTime          time.Time
 	Verbose            bool
+	Profile            string // active profile, switched with 'use'
+	ProjectDir         string // --project-dir the session was started with
+
+	stopWatch    context.CancelFunc  // stops the background watcher, nil when off
+	watchOptions docker.WatchOptions // of the running background watcher
 }
 
 func StartInteractiveSession() {
@@ -45,6 +54,8 @@ func StartInteractiveSession() {
 		ProjectInitialized: config.IsProjectInitialized(),
 		StartTime:          time.Now(),
 		CommandHistory:     previousHistory,
+		Profile:            config.Profile,
+		ProjectDir:         config.ProjectDir,
 	}
 
 	// Display JUDO banner
@@ -52,13 +63,14 @@ func StartInteractiveSession() {
 	fmt.Print(judobanner)
 	fmt.Println()
 
-	fmt.Printf("\x1b[1;36m🚀 JUDO CLI Interactive Session\x1b[0m\n")
-	fmt.Printf("\x1b[33mType 'help' for available commands, 'exit' to quit\x1b[0m\n\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[1;36m🚀 JUDO CLI Interactive Session\x1b[0m\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[33mType 'help' for available commands, 'exit' to quit\x1b[0m\n\n")
 
 	if state.ProjectInitialized {
-		fmt.Printf("\x1b[32m✅ Project initialized in: %s\x1b[0m\n", state.CurrentDir)
+		fmt.Fprintf(utils.Stdout, "\x1b[32m✅ Project initialized in: %s\x1b[0m\n", state.CurrentDir)
+		fmt.Fprintf(utils.Stdout, "\x1b[32m🎯 Profile: %s\x1b[0m\n", state.Profile)
 	} else {
-		fmt.Printf("\x1b[33m⚠️  No JUDO project found. Run 'init' to create one.\x1b[0m\n")
+		fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  No JUDO project found. Run 'init' to create one.\x1b[0m\n")
 	}
 	fmt.Println()
 
@@ -67,14 +79,14 @@ func StartInteractiveSession() {
 
 	// Create readline instance with tab completion
 	rl, err := readline.NewEx(&readline.Config{
-		Prompt:          getServiceStatusPrompt(),
+		Prompt:          utils.Colored(getServiceStatusPrompt(state)),
 		HistoryFile:     getHistoryFilePath(),
 		AutoComplete:    getCompleter(),
 		InterruptPrompt: "^C",
 		EOFPrompt:       "exit",
 	})
 	if err != nil {
-		fmt.Printf("\x1b[31mFailed to initialize readline: %v\x1b[0m\n", err)
+		fmt.Fprintf(utils.Stdout, "\x1b[31mFailed to initialize readline: %v\x1b[0m\n", err)
 		return
 	}
 	defer rl.Close()
@@ -83,7 +95,7 @@ func StartInteractiveSession() {
 		input, err := rl.Readline()
 		if err != nil {
 			if err == readline.ErrInterrupt {
-				fmt.Println("\x1b[33mType 'exit' to quit or continue typing\x1b[0m")
+				fmt.Fprintln(utils.Stdout, "\x1b[33mType 'exit' to quit or continue typing\x1b[0m")
 				continue
 			}
 			break
@@ -107,8 +119,9 @@ func StartInteractiveSession() {
 		// Handle session commands
 		switch input {
 		case "exit", "quit":
-			fmt.Printf("\x1b[33m👋 Session duration: %s\x1b[0m\n", time.Since(state.StartTime).Round(time.Second))
-			fmt.Printf("\x1b[33mCommands executed: %d\x1b[0m\n", len(state.CommandHistory))
+			stopBackgroundWatch(state)
+			fmt.Fprintf(utils.Stdout, "\x1b[33m👋 Session duration: %s\x1b[0m\n", time.Since(state.StartTime).Round(time.Second))
+			fmt.Fprintf(utils.Stdout, "\x1b[33mCommands executed: %d\x1b[0m\n", len(state.CommandHistory))
 			// Save session history before exiting
 			saveSessionHistory(state.CommandHistory)
 			return
@@ -125,12 +138,15 @@ func StartInteractiveSession() {
 			updateSessionStatus(state)
 			printSessionStatus(state)
 			continue
+		case "use":
+			printProfiles(state)
+			continue
 		case "doctor":
 			// Run doctor command in session mode
 			doctorCmd := commands.CreateDoctorCommand()
 			doctorCmd.SetArgs([]string{"--verbose"})
-			if err := executeCommandInSession(doctorCmd, []string{}); err != nil {
-				fmt.Printf("\x1b[31m❌ Doctor command failed: %v\x1b[0m\n", err)
+			if err := executeCommandInSession(doctorCmd, []string{}, state); err != nil {
+				fmt.Fprintf(utils.Stdout, "\x1b[31m❌ Doctor command failed: %v\x1b[0m\n", err)
 			}
 			continue
 		}
@@ -141,37 +157,61 @@ func StartInteractiveSession() {
 			continue
 		}
 
+		if args[0] == "use" {
+			if err := useProfile(state, args[1:]); err != nil {
+				fmt.Fprintf(utils.Stdout, "\x1b[31m❌ %v\x1b[0m\n", err)
+			} else if state.stopWatch != nil {
+				// the watcher follows the containers of the new profile
+				stopBackgroundWatch(state)
+				if err := startBackgroundWatch(state, utils.ColorWriter(rl.Stdout()), state.watchOptions); err != nil {
+					fmt.Fprintf(utils.Stdout, "\x1b[31m❌ %v\x1b[0m\n", err)
+				}
+			}
+			rl.SetPrompt(utils.Colored(getServiceStatusPrompt(state)))
+			continue
+		}
+
+		if args[0] == "watch" {
+			if err := backgroundWatch(state, utils.ColorWriter(rl.Stdout()), args[1:]); err != nil {
+				fmt.Fprintf(utils.Stdout, "\x1b[31m❌ %v\x1b[0m\n", err)
+			}
+			continue
+		}
+
 		// Find and execute the command
 		cmd, foundArgs, err := rootCmd.Find(args)
 		if err != nil {
-			fmt.Printf("\x1b[31mUnknown command: %s\x1b[0m\n", args[0])
-			fmt.Printf("\x1b[33mType 'help' for available commands or '%s?' for suggestions\x1b[0m\n", args[0])
+			fmt.Fprintf(utils.Stdout, "\x1b[31mUnknown command: %s\x1b[0m\n", args[0])
+			fmt.Fprintf(utils.Stdout, "\x1b[33mType 'help' for available commands or '%s?' for suggestions\x1b[0m\n", args[0])
 			continue
 		}
 
 		if cmd.Use == "session" {
-			fmt.Printf("\x1b[33m⚠️  Warning: Cannot execute session command within session\x1b[0m\n")
+			fmt.Fprintf(utils.Stdout, "\x1b[33m⚠️  Warning: Cannot execute session command within session\x1b[0m\n")
 			continue
 		}
 
 		// Show command execution feedback
-		fmt.Printf("\x1b[36m⚡ Executing: %s\x1b[0m\n", input)
+		fmt.Fprintf(utils.Stdout, "\x1b[36m⚡ Executing: %s\x1b[0m\n", input)
 		startTime := time.Now()
 
 		// Execute the command directly without going through the full Execute() flow
 		// This avoids command parsing conflicts within the session context
-		err = executeCommandInSession(cmd, foundArgs)
+		err = executeCommandInSession(cmd, foundArgs, state)
 		if err != nil {
-			fmt.Printf("\x1b[31m❌ Command failed after %s: %v\x1b[0m\n", time.Since(startTime).Round(time.Millisecond), err)
+			fmt.Fprintf(utils.Stdout, "\x1b[31m❌ Command failed after %s: %v\x1b[0m\n", time.Since(startTime).Round(time.Millisecond), err)
+			if hint := commands.ErrorHint(err); hint != "" {
+				fmt.Fprintf(utils.Stdout, "\x1b[33m   %s\x1b[0m\n", hint)
+			}
 		} else {
-			fmt.Printf("\x1b[32m✅ Command completed successfully in %s\x1b[0m\n", time.Since(startTime).Round(time.Millisecond))
+			fmt.Fprintf(utils.Stdout, "\x1b[32m✅ Command completed successfully in %s\x1b[0m\n", time.Since(startTime).Round(time.Millisecond))
 		}
 
 		// Update session state after command execution
 		updateSessionStatus(state)
 
 		// Update the prompt to reflect current service status
-		rl.SetPrompt(getServiceStatusPrompt())
+		rl.SetPrompt(utils.Colored(getServiceStatusPrompt(state)))
 	}
 }
 
@@ -202,12 +242,20 @@ func createSessionRootCommand() *cobra.Command {
 		commands.CreateStartCommand(),
 		commands.CreateStopCommand(),
 		commands.CreateStatusCommand(),
+		commands.CreatePsCommand(),
+		commands.CreateComposeCommand(),
+		commands.CreateImagesCommand(),
 		commands.CreateLogCommand(),
 		commands.CreateInitCommand(),
+		commands.CreateConfigCommand(),
+		commands.CreateWatchServicesCommand(),
 	)
 
 	// Add session-specific flags
-	rootCmd.PersistentFlags().StringVarP(&config.Profile, "env", "e", "judo", "Use alternate environment")
+	rootCmd.PersistentFlags().StringVarP(&config.Profile, "env", "e", config.DefaultProfile(), "Use alternate environment (default from JUDO_PROFILE)")
+	rootCmd.PersistentFlags().StringVarP(&config.ProjectDir, "project-dir", "C", "", "Run as if judo was started in this directory")
+	rootCmd.PersistentFlags().StringVar(&config.Options.PullPolicy, "pull", docker.PullMissing, "Image pull policy: missing | always")
+	rootCmd.PersistentFlags().BoolVar(&config.Options.Offline, "offline", false, "Never pull images; use only locally available ones")
 
 	return rootCmd
 }
@@ -218,55 +266,63 @@ func printSessionHelp() {
 	fmt.Print(judobanner)
 	fmt.Println()
 
-	fmt.Printf("\x1b[1;36m📋 JUDO CLI Session Commands:\x1b[0m\n")
-	fmt.Printf("\x1b[32m  help\x1b[0m      - Show this help message\n")
-	fmt.Printf("\x1b[32m  exit\x1b[0m      - Exit the interactive session\n")
-	fmt.Printf("\x1b[32m  quit\x1b[0m      - Exit the interactive session\n")
-	fmt.Printf("\x1b[32m  clear\x1b[0m     - Clear the terminal screen\n")
-	fmt.Printf("\x1b[32m  history\x1b[0m   - Show command history\n")
-	fmt.Printf("\x1b[32m  status\x1b[0m    - Show current session status\n")
-	fmt.Printf("\x1b[32m  doctor\x1b[0m    - Run system health check\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[1;36m📋 JUDO CLI Session Commands:\x1b[0m\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  help\x1b[0m      - Show this help message\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  exit\x1b[0m      - Exit the interactive session\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  quit\x1b[0m      - Exit the interactive session\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  clear\x1b[0m     - Clear the terminal screen\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  history\x1b[0m   - Show command history\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  status\x1b[0m    - Show current session status\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  doctor\x1b[0m    - Run system health check\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  use\x1b[0m       - Switch profile (use <profile>) or list profiles\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  watch\x1b[0m     - Report crashed services in the background (watch on|off [--restart])\n")
 	fmt.Println()
-	fmt.Printf("\x1b[1;36m🔧 Project Commands:\x1b[0m\n")
-	fmt.Printf("\x1b[32m  init\x1b[0m      - Initialize a new JUDO project\n")
-	fmt.Printf("\x1b[32m  build\x1b[0m     - Build project\n")
-	fmt.Printf("\x1b[32m  start\x1b[0m     - Start application\n")
-	fmt.Printf("\x1b[32m  stop\x1b[0m      - Stop application\n")
-	fmt.Printf("\x1b[32m  status\x1b[0m    - Show application status\n")
-	fmt.Printf("\x1b[32m  clean\x1b[0m     - Clean project data\n")
-	fmt.Printf("\x1b[32m  generate\x1b[0m  - Generate application from model\n")
-	fmt.Printf("\x1b[32m  dump\x1b[0m      - Dump PostgreSQL database\n")
-	fmt.Printf("\x1b[32m  import\x1b[0m    - Import PostgreSQL database dump\n")
-	fmt.Printf("\x1b[32m  update\x1b[0m    - Update dependency versions\n")
-	fmt.Printf("\x1b[32m  prune\x1b[0m     - Clean untracked files\n")
-	fmt.Printf("\x1b[32m  reckless\x1b[0m  - Fast build & run mode\n")
-	fmt.Printf("\x1b[32m  self-update\x1b[0m - Update CLI to latest version\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[1;36m🔧 Project Commands:\x1b[0m\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  init\x1b[0m      - Initialize a new JUDO project\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  build\x1b[0m     - Build project\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  start\x1b[0m     - Start application\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  stop\x1b[0m      - Stop application\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  status\x1b[0m    - Show application status\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  ps\x1b[0m        - List JUDO Docker resources of all projects\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  compose\x1b[0m   - List compose environments (compose envs)\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  images\x1b[0m    - List, save or load project images\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  watch-services\x1b[0m - Report crashed services until Ctrl-C\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  clean\x1b[0m     - Clean project data\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  generate\x1b[0m  - Generate application from model\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  dump\x1b[0m      - Dump PostgreSQL database\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  import\x1b[0m    - Import PostgreSQL database dump\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  update\x1b[0m    - Update dependency versions\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  prune\x1b[0m     - Clean untracked files\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  reckless\x1b[0m  - Fast build & run mode\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  self-update\x1b[0m - Update CLI to latest version\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  config\x1b[0m    - Show or edit configuration\n")
 	fmt.Println()
-	fmt.Printf("\x1b[33m💡 Type any JUDO command directly to execute it\x1b[0m\n")
-	fmt.Printf("\x1b[33m💡 Press TAB for auto-completion of commands and flags\x1b[0m\n")
-	fmt.Printf("\x1b[33m💡 Type '<command>?' to see detailed suggestions\x1b[0m\n")
-	fmt.Printf("\x1b[33m💡 Use 'history' to see previously executed commands\x1b[0m\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[33m💡 Type any JUDO command directly to execute it\x1b[0m\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[33m💡 Press TAB for auto-completion of commands and flags\x1b[0m\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[33m💡 Type '<command>?' to see detailed suggestions\x1b[0m\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[33m💡 Use 'history' to see previously executed commands\x1b[0m\n")
 }
 
 func printCommandHistory(history []string) {
 	if len(history) == 0 {
-		fmt.Printf("\x1b[33mNo commands in history\x1b[0m\n")
+		fmt.Fprintf(utils.Stdout, "\x1b[33mNo commands in history\x1b[0m\n")
 		return
 	}
 
-	fmt.Printf("\x1b[1;36m📜 Command History:\x1b[0m\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[1;36m📜 Command History:\x1b[0m\n")
 	for i, cmd := range history {
-		fmt.Printf("\x1b[32m%3d\x1b[0m: %s\n", i+1, cmd)
+		fmt.Fprintf(utils.Stdout, "\x1b[32m%3d\x1b[0m: %s\n", i+1, cmd)
 	}
 }
 
 // getCommandSuggestions returns command suggestions for auto-completion
 func getCommandSuggestions(input string) []string {
 	commands := []string{
-		"help", "exit", "quit", "clear", "history", "status", "doctor",
+		"help", "exit", "quit", "clear", "history", "status", "doctor", "use", "watch",
 		"init", "build", "start", "stop", "clean", "prune", "update",
 		"generate", "generate-root", "dump", "import", "schema-upgrade",
-		"reckless", "self-update",
+		"reckless", "self-update", "config", "ps", "compose", "images",
+		"watch-services",
 	}
 
 	var suggestions []string
@@ -306,10 +362,20 @@ func getArgumentSuggestions(commandLine string) []string {
 			"--quick", "-q",
 			"--ignore-checksum", "-i",
 		}
+	case "stop":
+		return []string{
+			"--volumes",
+		}
+	case "clean":
+		return []string{
+			"--dry-run", "--only", "--keep", "--yes", "-y",
+		}
 	case "start":
 		return []string{
 			"--skip-keycloak",
 			"--skip-watch-bundles",
+			"--recreate",
+			"--auto-ports",
 			"--options",
 		}
 	case "doctor":
@@ -335,6 +401,34 @@ func getArgumentSuggestions(commandLine string) []string {
 		return []string{
 			"--dump-name", "-n",
 		}
+	case "ps":
+		return []string{
+			"--prune-orphans", "--yes", "-y",
+		}
+	case "compose":
+		return []string{
+			"envs",
+		}
+	case "images":
+		return []string{
+			"list", "save", "load", "--output", "-o",
+		}
+	case "watch":
+		return []string{
+			"on", "off", "--restart",
+		}
+	case "watch-services":
+		return []string{
+			"--restart", "--max-restarts", "--lines", "-n",
+		}
+	case "use":
+		return config.AvailableProfiles(config.GetConfig().Root())
+	case "config":
+		return []string{
+			"show", "get", "set", "unset", "validate", "migrate",
+			"--dry-run", "--global", "--show-secrets",
+			"--origin",
+		}
 	default:
 		return []string{}
 	}
@@ -365,14 +459,14 @@ func getHistoryBasedSuggestions(input string, history []string) []string {
 func showCommandSuggestions(input string) {
 	suggestions := getCommandSuggestions(input)
 	if len(suggestions) > 0 {
-		fmt.Printf("\n\x1b[36m💡 Command Suggestions:\x1b[0m\n")
+		fmt.Fprintf(utils.Stdout, "\n\x1b[36m💡 Command Suggestions:\x1b[0m\n")
 		for _, suggestion := range suggestions {
-			fmt.Printf("  \x1b[32m%s\x1b[0m\n", suggestion)
+			fmt.Fprintf(utils.Stdout, "  \x1b[32m%s\x1b[0m\n", suggestion)
 		}
 		fmt.Println()
 	} else {
-		fmt.Printf("\x1b[33m❓ No matching commands found for '%s'\x1b[0m\n", input)
-		fmt.Printf("\x1b[33m💡 Type 'help' to see all available commands\x1b[0m\n")
+		fmt.Fprintf(utils.Stdout, "\x1b[33m❓ No matching commands found for '%s'\x1b[0m\n", input)
+		fmt.Fprintf(utils.Stdout, "\x1b[33m💡 Type 'help' to see all available commands\x1b[0m\n")
 	}
 }
 
@@ -383,32 +477,32 @@ func showEnhancedSuggestions(input string, history []string) {
 	argSuggestions := getArgumentSuggestions(input)
 
 	if len(cmdSuggestions) > 0 || len(historySuggestions) > 0 || len(argSuggestions) > 0 {
-		fmt.Printf("\n\x1b[36m💡 Suggestions for '%s':\x1b[0m\n", input)
+		fmt.Fprintf(utils.Stdout, "\n\x1b[36m💡 Suggestions for '%s':\x1b[0m\n", input)
 
 		if len(cmdSuggestions) > 0 {
-			fmt.Printf("\x1b[1;36m📝 Commands:\x1b[0m\n")
+			fmt.Fprintf(utils.Stdout, "\x1b[1;36m📝 Commands:\x1b[0m\n")
 			for _, suggestion := range cmdSuggestions {
-				fmt.Printf("  \x1b[32m%s\x1b[0m\n", suggestion)
+				fmt.Fprintf(utils.Stdout, "  \x1b[32m%s\x1b[0m\n", suggestion)
 			}
 		}
 
 		if len(argSuggestions) > 0 {
-			fmt.Printf("\x1b[1;36m⚙️  Arguments:\x1b[0m\n")
+			fmt.Fprintf(utils.Stdout, "\x1b[1;36m⚙️  Arguments:\x1b[0m\n")
 			for _, suggestion := range argSuggestions {
-				fmt.Printf("  \x1b[34m%s\x1b[0m\n", suggestion)
+				fmt.Fprintf(utils.Stdout, "  \x1b[34m%s\x1b[0m\n", suggestion)
 			}
 		}
 
 		if len(historySuggestions) > 0 {
-			fmt.Printf("\x1b[1;36m📜 From History:\x1b[0m\n")
+			fmt.Fprintf(utils.Stdout, "\x1b[1;36m📜 From History:\x1b[0m\n")
 			for _, suggestion := range historySuggestions {
-				fmt.Printf("  \x1b[33m%s\x1b[0m\n", suggestion)
+				fmt.Fprintf(utils.Stdout, "  \x1b[33m%s\x1b[0m\n", suggestion)
 			}
 		}
 		fmt.Println()
 	} else {
-		fmt.Printf("\x1b[33m❓ No matching commands found for '%s'\x1b[0m\n", input)
-		fmt.Printf("\x1b[33m💡 Type 'help' to see all available commands\x1b[0m\n")
+		fmt.Fprintf(utils.Stdout, "\x1b[33m❓ No matching commands found for '%s'\x1b[0m\n", input)
+		fmt.Fprintf(utils.Stdout, "\x1b[33m💡 Type 'help' to see all available commands\x1b[0m\n")
 	}
 }
 
@@ -419,31 +513,33 @@ func updateSessionStatus(state *SessionState) {
 
 // printSessionStatus displays detailed session information
 func printSessionStatus(state *SessionState) {
-	fmt.Printf("\x1b[1;36m📊 Session Status:\x1b[0m\n")
-	fmt.Printf("\x1b[32m  Session Duration:\x1b[0m %s\n", time.Since(state.StartTime).Round(time.Second))
-	fmt.Printf("\x1b[32m  Commands Executed:\x1b[0m %d\n", len(state.CommandHistory))
-	fmt.Printf("\x1b[32m  Current Directory:\x1b[0m %s\n", state.CurrentDir)
+	fmt.Fprintf(utils.Stdout, "\x1b[1;36m📊 Session Status:\x1b[0m\n")
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  Session Duration:\x1b[0m %s\n", time.Since(state.StartTime).Round(time.Second))
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  Commands Executed:\x1b[0m %d\n", len(state.CommandHistory))
+	fmt.Fprintf(utils.Stdout, "\x1b[32m  Current Directory:\x1b[0m %s\n", state.CurrentDir)
 
 	if state.ProjectInitialized {
-		fmt.Printf("\x1b[32m  JUDO Project:\x1b[0m ✅ Initialized\n")
+		fmt.Fprintf(utils.Stdout, "\x1b[32m  JUDO Project:\x1b[0m ✅ Initialized\n")
 
 		// Show project info if available
 		if cfg := config.GetConfig(); cfg != nil {
-			fmt.Printf("\x1b[32m  App Name:\x1b[0m %s\n", cfg.AppName)
-			fmt.Printf("\x1b[32m  Runtime:\x1b[0m %s\n", cfg.Runtime)
-			fmt.Printf("\x1b[32m  Database:\x1b[0m %s\n", cfg.DBType)
+			fmt.Fprintf(utils.Stdout, "\x1b[32m  Project Root:\x1b[0m %s\n", cfg.Root())
+			fmt.Fprintf(utils.Stdout, "\x1b[32m  Profile:\x1b[0m %s\n", profileDescription(cfg))
+			fmt.Fprintf(utils.Stdout, "\x1b[32m  App Name:\x1b[0m %s\n", cfg.AppName)
+			fmt.Fprintf(utils.Stdout, "\x1b[32m  Runtime:\x1b[0m %s\n", cfg.Runtime)
+			fmt.Fprintf(utils.Stdout, "\x1b[32m  Database:\x1b[0m %s\n", cfg.DBType)
 		}
 	} else {
-		fmt.Printf("\x1b[33m  JUDO Project:\x1b[0m ⚠️  Not initialized (run 'init' to create)\n")
+		fmt.Fprintf(utils.Stdout, "\x1b[33m  JUDO Project:\x1b[0m ⚠️  Not initialized (run 'init' to create)\n")
 	}
 
 	if len(state.CommandHistory) > 0 {
 		lastCmd := state.CommandHistory[len(state.CommandHistory)-1]
-		fmt.Printf("\x1b[32m  Last Command:\x1b[0m %s\n", lastCmd)
+		fmt.Fprintf(utils.Stdout, "\x1b[32m  Last Command:\x1b[0m %s\n", lastCmd)
 	}
 
 	if state.Verbose {
-		fmt.Printf("\x1b[32m  Verbose Mode:\x1b[0m Enabled\n")
+		fmt.Fprintf(utils.Stdout, "\x1b[32m  Verbose Mode:\x1b[0m Enabled\n")
 	}
 }
 
@@ -525,6 +621,18 @@ func getCompleter() readline.AutoCompleter {
 		readline.PcItem("clear"),
 		readline.PcItem("history"),
 		readline.PcItem("status"),
+		readline.PcItem("use", readline.PcItemDynamic(func(string) []string {
+			return config.AvailableProfiles(config.GetConfig().Root())
+		})),
+		readline.PcItem("watch",
+			readline.PcItem("on", readline.PcItem("--restart")),
+			readline.PcItem("off"),
+		),
+		readline.PcItem("watch-services",
+			readline.PcItem("--restart"),
+			readline.PcItem("--max-restarts"),
+			readline.PcItem("--lines", readline.PcItem("-n")),
+		),
 		readline.PcItem("log",
 			readline.PcItem("--tail", readline.PcItem("-t")),
 			readline.PcItem("--follow", readline.PcItem("-f")),
@@ -551,10 +659,31 @@ func getCompleter() readline.AutoCompleter {
 		readline.PcItem("start",
 			readline.PcItem("--skip-keycloak"),
 			readline.PcItem("--skip-watch-bundles"),
+			readline.PcItem("--recreate"),
+			readline.PcItem("--auto-ports"),
 			readline.PcItem("--options"),
 		),
-		readline.PcItem("stop"),
-		readline.PcItem("clean"),
+		readline.PcItem("stop",
+			readline.PcItem("--volumes"),
+		),
+		readline.PcItem("ps",
+			readline.PcItem("--prune-orphans"),
+			readline.PcItem("--yes"),
+		),
+		readline.PcItem("compose",
+			readline.PcItem("envs"),
+		),
+		readline.PcItem("images",
+			readline.PcItem("list"),
+			readline.PcItem("save", readline.PcItem("--output", readline.PcItem("-o"))),
+			readline.PcItem("load"),
+		),
+		readline.PcItem("clean",
+			readline.PcItem("--dry-run"),
+			readline.PcItem("--only"),
+			readline.PcItem("--keep"),
+			readline.PcItem("--yes", readline.PcItem("-y")),
+		),
 		readline.PcItem("prune",
 			readline.PcItem("--frontend", readline.PcItem("-f")),
 			readline.PcItem("--yes", readline.PcItem("-y")),
@@ -574,6 +703,18 @@ func getCompleter() readline.AutoCompleter {
 		),
 		readline.PcItem("schema-upgrade"),
 		readline.PcItem("reckless"),
+		readline.PcItem("config",
+			readline.PcItem("show",
+				readline.PcItem("--origin"),
+			),
+			readline.PcItem("get", configKeyCompletions()...),
+			readline.PcItem("set", append(configKeyCompletions(), readline.PcItem("--global"))...),
+			readline.PcItem("unset", append(configKeyCompletions(), readline.PcItem("--global"))...),
+			readline.PcItem("validate"),
+			readline.PcItem("migrate",
+				readline.PcItem("--dry-run"),
+			),
+		),
 		readline.PcItem("self-update",
 			readline.PcItem("--check", readline.PcItem("-c")),
 			readline.PcItem("--force", readline.PcItem("-f")),
@@ -581,8 +722,124 @@ func getCompleter() readline.AutoCompleter {
 	)
 }
 
+// configKeyCompletions returns completion items for every known configuration key
+func configKeyCompletions() []readline.PrefixCompleterInterface {
+	var items []readline.PrefixCompleterInterface
+	for _, k := range config.Keys() {
+		items = append(items, readline.PcItem(k))
+	}
+	return items
+}
+
+// useProfile switches the session to another profile and reloads the
+// configuration. Without arguments it lists the available profiles.
+func useProfile(state *SessionState, args []string) error {
+	if len(args) == 0 {
+		printProfiles(state)
+		return nil
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("usage: use <profile>")
+	}
+	profile := strings.TrimSuffix(args[0], ".properties")
+	available := config.AvailableProfiles(config.GetConfig().Root())
+	found := false
+	for _, p := range available {
+		if p == profile {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown profile %q (available: %s)", profile, strings.Join(available, ", "))
+	}
+
+	state.Profile = profile
+	config.Profile = profile
+	cfg := config.Reload()
+	fmt.Fprintf(utils.Stdout, "\x1b[32m✅ Using profile %s\x1b[0m\n", profileDescription(cfg))
+	return nil
+}
+
+// backgroundWatch handles 'watch [on|off] [--restart]': the service watcher
+// runs in the background of the session and prints its reports to w, above
+// the prompt.
+func backgroundWatch(state *SessionState, w io.Writer, args []string) error {
+	on := true
+	opts := docker.WatchOptions{MaxRestarts: 3, LogLines: 20}
+	for _, arg := range args {
+		switch arg {
+		case "on":
+			on = true
+		case "off":
+			on = false
+		case "--restart":
+			opts.Restart = true
+		default:
+			return fmt.Errorf("usage: watch [on|off] [--restart]")
+		}
+	}
+	if !on {
+		if state.stopWatch == nil {
+			fmt.Println("The service watcher is not running")
+			return nil
+		}
+		stopBackgroundWatch(state)
+		fmt.Println("Service watcher stopped")
+		return nil
+	}
+	stopBackgroundWatch(state)
+	return startBackgroundWatch(state, w, opts)
+}
+
+func startBackgroundWatch(state *SessionState, w io.Writer, opts docker.WatchOptions) error {
+	if !config.IsProjectInitialized() {
+		return fmt.Errorf("no JUDO project found; the watcher needs a project")
+	}
+	if !docker.IsDockerRunning(context.Background()) {
+		return docker.ErrDaemonUnreachable
+	}
+	cfg := config.GetConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	state.stopWatch, state.watchOptions = cancel, opts
+	go func() {
+		if err := docker.WatchServices(ctx, cfg, opts, w); err != nil {
+			fmt.Fprintf(w, "\x1b[31m❌ Service watcher stopped: %v\x1b[0m\n", err)
+		}
+	}()
+	fmt.Fprintf(utils.Stdout, "\x1b[32m👀 Watching the services of profile %s in the background ('watch off' to stop)\x1b[0m\n", state.Profile)
+	return nil
+}
+
+func stopBackgroundWatch(state *SessionState) {
+	if state.stopWatch != nil {
+		state.stopWatch()
+		state.stopWatch = nil
+	}
+}
+
+// printProfiles lists the available profiles and marks the active one
+func printProfiles(state *SessionState) {
+	fmt.Fprintf(utils.Stdout, "\x1b[1;36m🎯 Profiles:\x1b[0m\n")
+	for _, p := range config.AvailableProfiles(config.GetConfig().Root()) {
+		if p == state.Profile {
+			fmt.Fprintf(utils.Stdout, "\x1b[32m* %s\x1b[0m\n", p)
+		} else {
+			fmt.Printf("  %s\n", p)
+		}
+	}
+}
+
+// profileDescription returns the active profile with the file it reads
+func profileDescription(cfg *config.Config) string {
+	if path := cfg.PropertiesPath(); filepath.Base(path) != "judo.properties" {
+		return fmt.Sprintf("%s (%s)", cfg.Profile, filepath.Base(path))
+	}
+	return cfg.Profile
+}
+
 // getServiceStatusPrompt generates a prompt with service status indicators
-func getServiceStatusPrompt() string {
+func getServiceStatusPrompt(state *SessionState) string {
 	if !config.IsProjectInitialized() {
 		return "\x1b[1;34mjudo>\x1b[0m "
 	}
@@ -593,26 +850,28 @@ func getServiceStatusPrompt() string {
 	// Check Karaf status
 	karafRunning := false
 	if cfg.Runtime == "karaf" {
-		karafDir := filepath.Join(cfg.ModelDir, "application", ".karaf")
-		karafRunning = karaf.KarafRunning(karafDir)
+		karafRunning = karaf.KarafRunning(cfg.KarafDir)
 	}
 	statusParts = append(statusParts, fmt.Sprintf("%skaraf:%s", getServiceEmoji("karaf"), getStatusColor(karafRunning)))
 
 	// Check Keycloak status
 	keycloakRunning := false
-	keycloakName := "keycloak-" + cfg.KeycloakName
-	keycloakRunning = docker.DockerInstanceRunning(keycloakName)
+	keycloakName := cfg.Names().KeycloakContainer
+	keycloakRunning = docker.DockerInstanceRunning(context.Background(), keycloakName)
 	statusParts = append(statusParts, fmt.Sprintf("%skeycloak:%s", getServiceEmoji("keycloak"), getStatusColor(keycloakRunning)))
 
 	// Check PostgreSQL status (if using PostgreSQL)
 	postgresRunning := false
 	if cfg.DBType == "postgresql" {
-		postgresName := "postgres-" + cfg.SchemaName
-		postgresRunning = docker.DockerInstanceRunning(postgresName)
+		postgresName := cfg.Names().PostgresContainer
+		postgresRunning = docker.DockerInstanceRunning(context.Background(), postgresName)
 		statusParts = append(statusParts, fmt.Sprintf("%spostgres:%s", getServiceEmoji("postgres"), getStatusColor(postgresRunning)))
 	}
 
 	statusStr := strings.Join(statusParts, " ")
+	if state.Profile != "" && state.Profile != "judo" {
+		return fmt.Sprintf("\x1b[1;34mjudo:%s [%s]>\x1b[0m ", state.Profile, statusStr)
+	}
 	return fmt.Sprintf("\x1b[1;34mjudo [%s]>\x1b[0m ", statusStr)
 }
 
@@ -638,13 +897,61 @@ func getServiceEmoji(service string) string {
 	}
 }
 
+// resetFlags sets every flag of cmd, including the inherited ones, back to
+// its default and clears Changed.
+func resetFlags(cmd *cobra.Command) error {
+	var errs []error
+	reset := func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			var def []string
+			if d := strings.Trim(f.DefValue, "[]"); d != "" {
+				def = strings.Split(d, ",")
+			}
+			errs = append(errs, sv.Replace(def))
+		} else {
+			errs = append(errs, f.Value.Set(f.DefValue))
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.InheritedFlags().VisitAll(reset)
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to reset flags of %s: %w", cmd.Name(), err)
+	}
+	return nil
+}
+
 // executeCommandInSession executes a command within the session context
 // without going through the full cobra Execute() flow to avoid conflicts
-func executeCommandInSession(cmd *cobra.Command, args []string) error {
+func executeCommandInSession(cmd *cobra.Command, args []string, state *SessionState) error {
+	// the command tree is reused, so flags of the previous call (clean --yes,
+	// stop --volumes, ...) must not carry over
+	if err := resetFlags(cmd); err != nil {
+		return err
+	}
+	// -e/--env and -C given on a single command only apply to that command;
+	// the configuration is reloaded before and after it
+	config.Profile, config.ProjectDir = state.Profile, state.ProjectDir
+	config.Options.PullPolicy, config.Options.Offline = docker.PullMissing, false
+	config.Options.Recreate = false
+	// Ctrl-C cancels the running command, not the session
+	ctx, stop := utils.InterruptContext(context.Background())
+	defer stop()
+	cmd.SetContext(ctx)
+	defer func() {
+		config.Profile, config.ProjectDir = state.Profile, state.ProjectDir
+		config.Reload()
+	}()
+
 	// Set up the command flags from the remaining arguments
 	if err := cmd.ParseFlags(args); err != nil {
 		return fmt.Errorf("failed to parse flags: %w", err)
 	}
+	config.Reload()
+	args = cmd.Flags().Args()
+	if err := cmd.ValidateArgs(args); err != nil {
+		return err
+	}
 
 	// Run the persistent pre-run hooks if they exist
 	if cmd.PersistentPreRun != nil {
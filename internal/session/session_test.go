@@ -0,0 +1,91 @@
+package session
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"judo-cli-module/internal/commands"
+	"judo-cli-module/internal/utils"
+)
+
+// runInSession runs cmd through the session once per call, like commands
+// typed one after the other, and records the flags it saw each time.
+func runInSession(t *testing.T, cmd *cobra.Command, record func(cmd *cobra.Command) string, calls ...[]string) []string {
+	t.Setenv("HOME", t.TempDir())
+	state := &SessionState{ProjectDir: t.TempDir()}
+	var seen []string
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		seen = append(seen, record(cmd))
+		return nil
+	}
+	for _, args := range calls {
+		require.NoError(t, executeCommandInSession(cmd, args, state))
+	}
+	return seen
+}
+
+func TestSessionResetsCleanFlags(t *testing.T) {
+	seen := runInSession(t, commands.CreateCleanCommand(), func(cmd *cobra.Command) string {
+		yes, _ := cmd.Flags().GetBool("yes")
+		only, _ := cmd.Flags().GetStringSlice("only")
+		return fmt.Sprintf("yes=%t only=%v changed=%t", yes, only, cmd.Flags().Changed("yes"))
+	}, []string{"--yes", "--only", "postgres"}, []string{"--only", "karaf"}, nil)
+	assert.Equal(t, []string{
+		"yes=true only=[postgres] changed=true",
+		"yes=false only=[karaf] changed=false",
+		"yes=false only=[] changed=false",
+	}, seen)
+}
+
+func TestSessionResetsStopVolumes(t *testing.T) {
+	seen := runInSession(t, commands.CreateStopCommand(), func(cmd *cobra.Command) string {
+		volumes, _ := cmd.Flags().GetBool("volumes")
+		return fmt.Sprintf("volumes=%t", volumes)
+	}, []string{"--volumes"}, nil)
+	assert.Equal(t, []string{"volumes=true", "volumes=false"}, seen)
+}
+
+func TestSessionResetsPsPruneFlags(t *testing.T) {
+	seen := runInSession(t, commands.CreatePsCommand(), func(cmd *cobra.Command) string {
+		prune, _ := cmd.Flags().GetBool("prune-orphans")
+		yes, _ := cmd.Flags().GetBool("yes")
+		return fmt.Sprintf("prune-orphans=%t yes=%t", prune, yes)
+	}, []string{"--prune-orphans", "-y"}, nil)
+	assert.Equal(t, []string{"prune-orphans=true yes=true", "prune-orphans=false yes=false"}, seen)
+}
+
+// runSessionLine looks up line in the session command tree like the session
+// prompt does and returns what the command printed.
+func runSessionLine(t *testing.T, projectDir string, line ...string) string {
+	t.Setenv("HOME", t.TempDir())
+	var out bytes.Buffer
+	stdout := utils.Stdout
+	utils.Stdout = &out
+	t.Cleanup(func() { utils.Stdout = stdout })
+
+	cmd, args, err := createSessionRootCommand().Find(line)
+	require.NoError(t, err)
+	require.NoError(t, executeCommandInSession(cmd, args, &SessionState{ProjectDir: projectDir}))
+	return out.String()
+}
+
+func TestSessionRunsComposeEnvs(t *testing.T) {
+	project := t.TempDir()
+	dir := filepath.Join(project, "docker", "compose-develop")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services: {}\n"), 0o644))
+
+	assert.Contains(t, runSessionLine(t, project, "compose", "envs"), "compose-develop")
+}
+
+func TestSessionRunsImagesList(t *testing.T) {
+	out := runSessionLine(t, t.TempDir(), "images", "list")
+	assert.Contains(t, out, "keycloak")
+}
@@ -5,16 +5,20 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"strings"
+	"syscall"
 	"time"
 )
 
@@ -38,24 +42,78 @@ func NewScanner(r io.Reader) Scanner {
 	return &bufioScanner{bufio.NewScanner(r)}
 }
 
-func WaitForPort(host string, port int, timeout time.Duration) {
-	deadline := time.Now().Add(timeout)
+// TailFile returns the last n lines of a text file, or "" when it cannot be
+// read.
+func TailFile(path string, n int) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
 
-	fmt.Printf("Wait for port %d on %s.\n", port, host)
-	for {
-		c, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 2*time.Second)
-		if err == nil {
-			_ = c.Close()
-			fmt.Println()
-			return
-		}
-		if time.Now().After(deadline) {
-			fmt.Println("\nWait timed out.")
-			log.Fatal("waitForPort timed out")
-		}
-		fmt.Print(".")
-		time.Sleep(1 * time.Second)
+var ansiColor = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// IsTerminal reports whether f is an interactive terminal.
+func IsTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// InterruptContext returns a context that is cancelled on Ctrl-C or SIGTERM,
+// so a running command can stop and clean up. Only the first signal is
+// caught; a second one terminates the process as usual.
+func InterruptContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// Stdout and Stderr are where the CLI prints its own, possibly colored,
+// output. They strip ANSI color codes after SetColor(false); os.Stdout and
+// os.Stderr themselves are left alone, so child processes keep the terminal.
+var (
+	Stdout io.Writer = ColorWriter(os.Stdout)
+	Stderr io.Writer = ColorWriter(os.Stderr)
+)
+
+var colorEnabled = true
+
+// SetColor turns colored output of the CLI on or off.
+func SetColor(enabled bool) {
+	colorEnabled = enabled
+}
+
+// ColorWriter wraps w so it strips ANSI color codes while color is off.
+func ColorWriter(w io.Writer) io.Writer {
+	return colorWriter{w}
+}
+
+type colorWriter struct{ w io.Writer }
+
+func (c colorWriter) Write(p []byte) (int, error) {
+	if colorEnabled {
+		return c.w.Write(p)
+	}
+	if _, err := c.w.Write(ansiColor.ReplaceAll(p, nil)); err != nil {
+		return 0, err
 	}
+	return len(p), nil
+}
+
+// Colored returns s, or s without ANSI color codes while color is off.
+func Colored(s string) string {
+	if colorEnabled {
+		return s
+	}
+	return ansiColor.ReplaceAllString(s, "")
 }
 
 func CheckError(err error) {
@@ -95,13 +153,14 @@ func ExecuteCommand(name string, args ...string) *exec.Cmd {
 	return cmd
 }
 
-func GetProjectVersion() string {
+// GetProjectVersion evaluates the Maven project version of the project in dir.
+func GetProjectVersion(dir string) string {
 	var out bytes.Buffer
 	c := exec.Command("mvn",
 		"org.apache.maven.plugins:maven-help-plugin:3.2.0:evaluate",
 		"-Dexpression=project.version", "-q", "-DforceStdout",
 	)
-	c.Dir = "." // This will be set by the caller based on config.ModelDir
+	c.Dir = dir
 	c.Stdout = &out
 	c.Stderr = &out
 	if err := c.Run(); err != nil {
@@ -303,6 +362,22 @@ func PromptForSelection(prompt string, options []string, defaultOption string) s
 	}
 }
 
+// ErrPortInUse marks a service port that is taken by another process.
+var ErrPortInUse = errors.New("port is already in use")
+
+// PortInUseError is returned when the port of a service is taken by a
+// process that is not the service itself.
+type PortInUseError struct {
+	Service string
+	Port    int
+}
+
+func (e *PortInUseError) Error() string {
+	return fmt.Sprintf("%s port %d is already in use by another process", e.Service, e.Port)
+}
+
+func (e *PortInUseError) Is(target error) bool { return target == ErrPortInUse }
+
 // IsPortAvailable checks if a TCP port is available by attempting to connect to it.
 func IsPortAvailable(port int) bool {
 	address := fmt.Sprintf("127.0.0.1:%d", port)
@@ -318,6 +393,17 @@ func IsPortAvailable(port int) bool {
 	return false
 }
 
+// NextFreePort returns the first available port in [from, from+count) that is
+// not in skip.
+func NextFreePort(from, count int, skip map[int]bool) (int, bool) {
+	for port := from; port < from+count && port <= 65535; port++ {
+		if !skip[port] && IsPortAvailable(port) {
+			return port, true
+		}
+	}
+	return 0, false
+}
+
 // IsPortUsedByKaraf checks if a port is being used by the current Karaf instance
 func IsPortUsedByKaraf(port int, karafDir string) bool {
 	if karafDir == "" {